@@ -0,0 +1,167 @@
+package httpSwagger
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// maxAudienceEntries bounds the number of filtered documents cached per definition.
+const maxAudienceEntries = 256
+
+// AudienceConfig filters the served documents per caller.
+type AudienceConfig struct {
+	// Audience returns the audiences of the caller of r, e.g. the roles of a principal
+	// stored in its context. Requests it returns an error for are answered with
+	// `403 Forbidden`.
+	Audience func(r *http.Request) ([]string, error)
+	// Filter removes from doc what the audiences are not entitled to. The filtered
+	// documents are cached per set of audiences, so it must only depend on them.
+	// Defaults to FilterExtension("x-audience").
+	Filter func(r *http.Request, audiences []string, doc Document) error
+}
+
+// Audience filters the documents, and the definitions listed in URLs, per caller.
+func Audience(config AudienceConfig) func(*Config) {
+	return func(c *Config) {
+		if config.Audience == nil {
+			c.errs = append(c.errs, errors.New("audience function is required"))
+
+			return
+		}
+
+		if config.Filter == nil {
+			config.Filter = FilterExtension("x-audience")
+		}
+
+		c.Audience = &config
+	}
+}
+
+// FilterExtension returns a filter keeping the operations whose extension, a string or
+// a list of strings such as `x-audience: [partner]`, holds one of the audiences of the
+// caller. Operations without the extension are kept. The definitions only referenced
+// by removed operations are removed too.
+func FilterExtension(extension string) func(r *http.Request, audiences []string, doc Document) error {
+	return func(r *http.Request, audiences []string, doc Document) error {
+		doc.removeOperations(func(op map[string]interface{}) bool {
+			var required []string
+
+			switch v := op[extension].(type) {
+			case nil:
+				return false
+			case string:
+				required = []string{v}
+			case []interface{}:
+				for _, a := range v {
+					if s, ok := a.(string); ok {
+						required = append(required, s)
+					}
+				}
+			}
+
+			return !entitled(required, audiences)
+		})
+
+		return nil
+	}
+}
+
+// entitled reports whether one of audiences is required, or nothing is.
+func entitled(required, audiences []string) bool {
+	if len(required) == 0 {
+		return true
+	}
+
+	for _, r := range required {
+		for _, a := range audiences {
+			if r == a {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+type audiencesKey struct{}
+
+// withAudiences returns r with the audiences of its caller.
+func withAudiences(r *http.Request, audiences []string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), audiencesKey{}, audiences))
+}
+
+// requestAudiences returns the audiences of the caller of r.
+func requestAudiences(r *http.Request) []string {
+	audiences, _ := r.Context().Value(audiencesKey{}).([]string)
+
+	return audiences
+}
+
+// visibleURLs returns the definitions of urls the audiences are entitled to.
+func visibleURLs(urls []SpecURL, audiences []string) []SpecURL {
+	visible := make([]SpecURL, 0, len(urls))
+	for _, u := range urls {
+		if entitled(u.Audiences, audiences) {
+			visible = append(visible, u)
+		}
+	}
+
+	return visible
+}
+
+type audienceEntry struct {
+	source [sha256.Size]byte
+	result []byte
+}
+
+// audienceDoc filters the documents read by read for the audiences of each request.
+func audienceDoc(read docReader, config *AudienceConfig) docReader {
+	var (
+		mu      sync.Mutex
+		entries = make(map[string]audienceEntry)
+	)
+
+	return func(r *http.Request) ([]byte, error) {
+		doc, err := read(r)
+		if err != nil {
+			return nil, err
+		}
+
+		audiences := requestAudiences(r)
+
+		sorted := append([]string(nil), audiences...)
+		sort.Strings(sorted)
+		key := strings.Join(sorted, "\x00")
+		sum := sha256.Sum256(doc)
+
+		mu.Lock()
+		e, ok := entries[key]
+		mu.Unlock()
+
+		if ok && e.source == sum {
+			return e.result, nil
+		}
+
+		out, err := applyTransformers(doc, []DocTransformer{func(d Document) error {
+			return config.Filter(r, audiences, d)
+		}})
+		if err != nil {
+			return nil, err
+		}
+
+		mu.Lock()
+		if len(entries) >= maxAudienceEntries {
+			entries = make(map[string]audienceEntry)
+		}
+
+		entries[key] = audienceEntry{source: sum, result: out}
+		mu.Unlock()
+
+		return out, nil
+	}
+}
@@ -0,0 +1,121 @@
+package httpSwagger
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const audienceDocument = `{"swagger":"2.0","paths":{` +
+	`"/pets":{"get":{"responses":{}},"post":{"x-audience":"partner","responses":{}}},` +
+	`"/admin":{"delete":{"x-audience":["admin","ops"],"responses":{}}}}}`
+
+func performAudienceRequest(target, roles string, router http.Handler) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	if roles != "" {
+		r.Header.Set("X-Roles", roles)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	return w
+}
+
+func TestAudience(t *testing.T) {
+	filtered := 0
+	filter := FilterExtension("x-audience")
+
+	router := http.NewServeMux()
+	router.Handle("/swagger/", Handler(
+		Provider(BytesProvider([]byte(audienceDocument), "application/json")),
+		URLs(
+			SpecURL{Name: "Public", URL: "doc.json"},
+			SpecURL{Name: "Admin", InstanceName: "admin", Provider: BytesProvider([]byte(audienceDocument), ""), Audiences: []string{"admin"}},
+		),
+		PrimaryName("Admin"),
+		Audience(AudienceConfig{
+			Audience: func(r *http.Request) ([]string, error) {
+				if r.Header.Get("X-Roles") == "banned" {
+					return nil, errors.New("banned")
+				}
+
+				return strings.Fields(r.Header.Get("X-Roles")), nil
+			},
+			Filter: func(r *http.Request, audiences []string, doc Document) error {
+				filtered++
+
+				return filter(r, audiences, doc)
+			},
+		}),
+	))
+
+	w := performAudienceRequest("/swagger/doc.json", "", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"paths":{"/pets":{"get":{"responses":{}}}},"swagger":"2.0"}`, w.Body.String())
+
+	w = performAudienceRequest("/swagger/doc.json", "partner", router)
+	assert.Equal(t, `{"paths":{"/pets":{"get":{"responses":{}},"post":{"responses":{},"x-audience":"partner"}}},"swagger":"2.0"}`, w.Body.String())
+
+	w = performAudienceRequest("/swagger/doc.json", "ops partner", router)
+	assert.Contains(t, w.Body.String(), `"/admin":{"delete"`)
+	assert.Contains(t, w.Body.String(), `"post":{`)
+	assert.Equal(t, 3, filtered)
+
+	performAudienceRequest("/swagger/doc.yaml", "partner ops", router)
+	assert.Equal(t, 3, filtered)
+
+	w = performAudienceRequest("/swagger/index.html", "", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `urls: [{"name":"Public","url":"doc.json"}],`)
+	assert.NotContains(t, w.Body.String(), `"urls.primaryName"`)
+
+	w = performAudienceRequest("/swagger/index.html", "admin", router)
+	assert.Contains(t, w.Body.String(), `urls: [{"name":"Public","url":"doc.json"},{"name":"Admin","url":"admin.json"}],`)
+	assert.Contains(t, w.Body.String(), `"urls.primaryName": "Admin",`)
+
+	w = performAudienceRequest("/swagger/swagger-config.json", "", router)
+	assert.Contains(t, w.Body.String(), `"urls":[{"name":"Public","url":"doc.json"}],"deepLinking"`)
+
+	assert.Equal(t, http.StatusNotFound, performAudienceRequest("/swagger/admin.json", "partner", router).Code)
+	assert.Equal(t, http.StatusOK, performAudienceRequest("/swagger/admin.json", "admin", router).Code)
+	assert.Equal(t, http.StatusForbidden, performAudienceRequest("/swagger/index.html", "banned", router).Code)
+
+	_, err := NewHandler(Audience(AudienceConfig{}))
+	assert.EqualError(t, err, "invalid configuration: audience function is required")
+}
+
+func TestAudienceCaching(t *testing.T) {
+	router := http.NewServeMux()
+	router.Handle("/swagger/", Handler(
+		Provider(BytesProvider([]byte(`{"swagger":"2.0","paths":{`+
+			`"/pets":{"get":{"responses":{"200":{"schema":{"$ref":"#/definitions/Pet"}}}}},`+
+			`"/secret-export":{"post":{"x-audience":"partner","responses":{"200":{"schema":{"$ref":"#/definitions/SecretThing"}}}}}},`+
+			`"definitions":{"Pet":{"type":"object"},"SecretThing":{"type":"object"}}}`), "application/json")),
+		OpenAPI3(OpenAPI30),
+		CacheControl(CachePolicy{Index: "no-store", Spec: "public, max-age=600"}),
+		Audience(AudienceConfig{
+			Audience: func(r *http.Request) ([]string, error) {
+				return strings.Fields(r.Header.Get("X-Roles")), nil
+			},
+		}),
+	))
+
+	for _, path := range []string{"/swagger/doc.json", "/swagger/openapi.json"} {
+		w := performAudienceRequest(path, "", router)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "private, max-age=600", w.Header().Get("Cache-Control"))
+		assert.Contains(t, w.Body.String(), `"Pet"`)
+		assert.NotContains(t, w.Body.String(), "SecretThing")
+
+		w = performAudienceRequest(path, "partner", router)
+		assert.Contains(t, w.Body.String(), "SecretThing")
+	}
+
+	w := performAudienceRequest("/swagger/index.html", "", router)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+}
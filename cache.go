@@ -0,0 +1,187 @@
+package httpSwagger
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"io/fs"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CachePolicy holds the Cache-Control header values sent for each class of resource.
+// An empty value sends no Cache-Control header.
+type CachePolicy struct {
+	// Index applies to the rendered index page.
+	Index string
+	// Spec applies to the served API definitions.
+	Spec string
+	// Assets applies to the embedded Swagger UI assets.
+	Assets string
+}
+
+// CacheControl sets the Cache-Control header values sent for the index page,
+// the API definitions and the Swagger UI assets.
+func CacheControl(policy CachePolicy) func(*Config) {
+	return func(c *Config) {
+		c.CachePolicy = policy
+	}
+}
+
+// AssetsModTime sets the Last-Modified time of the embedded Swagger UI assets.
+// Defaults to the modification time of the running executable.
+func AssetsModTime(modTime time.Time) func(*Config) {
+	return func(c *Config) {
+		c.AssetsModTime = modTime
+	}
+}
+
+// privateCacheControl returns cacheControl restricted to the cache of the caller,
+// for responses which depend on the caller.
+func privateCacheControl(cacheControl string) string {
+	directives := []string{"private"}
+
+	for _, d := range strings.Split(cacheControl, ",") {
+		switch d = strings.TrimSpace(d); strings.ToLower(d) {
+		case "", "public", "private":
+		case "no-store":
+			directives[0] = d
+		default:
+			directives = append(directives, d)
+		}
+	}
+
+	return strings.Join(directives, ", ")
+}
+
+// strongETag returns a strong entity tag computed from content.
+func strongETag(content []byte) string {
+	sum := sha256.Sum256(content)
+
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// serveBytes writes content with a strong ETag and the given Cache-Control value,
+// answering conditional and range requests.
+func serveBytes(w http.ResponseWriter, r *http.Request, name string, modTime time.Time, content []byte, cacheControl string) {
+	serveEncoded(w, r, name, modTime, newEncoded(content, nil), cacheControl)
+}
+
+// executableModTime returns the modification time of the running executable,
+// which is when the embedded assets were built into it.
+func executableModTime() time.Time {
+	if exe, err := os.Executable(); err == nil {
+		if fi, err := os.Stat(exe); err == nil {
+			return fi.ModTime()
+		}
+	}
+
+	return time.Now()
+}
+
+//go:embed static
+var staticFS embed.FS
+
+// staticFiles holds the assets of this package served next to the Swagger UI ones.
+var staticFiles, _ = fs.Sub(staticFS, "static")
+
+// assetStore serves files from an fs.FS. Compressible files are loaded along with their
+// compressed variants when the store is created, any other file on first use.
+type assetStore struct {
+	fsys    fs.FS
+	modTime time.Time
+
+	mu     sync.Mutex
+	assets map[string]*encoded
+}
+
+func newAssetStore(fsys fs.FS, modTime time.Time, encodings []Encoding) *assetStore {
+	s := &assetStore{
+		fsys:    fsys,
+		modTime: modTime,
+		assets:  make(map[string]*encoded),
+	}
+
+	if len(encodings) == 0 {
+		return s
+	}
+
+	_ = fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !isCompressible(name) {
+			return err
+		}
+
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+
+		s.assets[name] = newEncoded(content, encodings)
+
+		return nil
+	})
+
+	return s
+}
+
+func (s *assetStore) get(name string) (*encoded, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a, ok := s.assets[name]; ok {
+		return a, nil
+	}
+
+	content, err := fs.ReadFile(s.fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	a := newEncoded(content, nil)
+	s.assets[name] = a
+
+	return a, nil
+}
+
+// contains reports whether the store holds a regular file called name.
+func (s *assetStore) contains(name string) bool {
+	if !fs.ValidPath(name) {
+		return false
+	}
+
+	s.mu.Lock()
+	_, ok := s.assets[name]
+	s.mu.Unlock()
+
+	if ok {
+		return true
+	}
+
+	info, err := fs.Stat(s.fsys, name)
+
+	return err == nil && info.Mode().IsRegular()
+}
+
+func (s *assetStore) serve(w http.ResponseWriter, r *http.Request, name, cacheControl string) {
+	if !fs.ValidPath(name) {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	a, err := s.get(name)
+	if err != nil {
+		if os.IsNotExist(err) {
+			http.NotFound(w, r)
+		} else {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	serveEncoded(w, r, name, s.modTime, a, cacheControl)
+}
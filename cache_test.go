@@ -0,0 +1,102 @@
+package httpSwagger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggo/swag"
+)
+
+func TestStrongETag(t *testing.T) {
+	assert.Equal(t, strongETag([]byte("a")), strongETag([]byte("a")))
+	assert.NotEqual(t, strongETag([]byte("a")), strongETag([]byte("b")))
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, strongETag([]byte("a")))
+}
+
+func TestPrivateCacheControl(t *testing.T) {
+	for cacheControl, exp := range map[string]string{
+		"":                            "private",
+		"public, max-age=600":         "private, max-age=600",
+		"Public,s-maxage=60":          "private, s-maxage=60",
+		"private, no-cache":           "private, no-cache",
+		"no-store":                    "no-store",
+		"max-age=60, must-revalidate": "private, max-age=60, must-revalidate",
+	} {
+		assert.Equal(t, exp, privateCacheControl(cacheControl), cacheControl)
+	}
+}
+
+func TestConditionalRequests(t *testing.T) {
+	swag.Register("cache", &mockedSwag{})
+
+	modTime := time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	router := http.NewServeMux()
+	router.Handle("/swagger/", Handler(
+		InstanceName("cache"),
+		AssetsModTime(modTime),
+		CacheControl(CachePolicy{
+			Index:  "no-cache",
+			Spec:   "public, max-age=60",
+			Assets: "public, max-age=86400",
+		}),
+	))
+
+	for _, test := range []struct {
+		target       string
+		cacheControl string
+	}{
+		{target: "/swagger/index.html", cacheControl: "no-cache"},
+		{target: "/swagger/doc.json", cacheControl: "public, max-age=60"},
+		{target: "/swagger/doc.yaml", cacheControl: "public, max-age=60"},
+		{target: "/swagger/swagger-ui.css", cacheControl: "public, max-age=86400"},
+	} {
+		w1 := performRequest(http.MethodGet, test.target, router)
+		assert.Equal(t, http.StatusOK, w1.Code, test.target)
+		assert.Equal(t, test.cacheControl, w1.Header().Get("Cache-Control"), test.target)
+
+		etag := w1.Header().Get("ETag")
+		assert.Equal(t, strongETag(w1.Body.Bytes()), etag, test.target)
+
+		r := httptest.NewRequest(http.MethodGet, test.target, nil)
+		r.Header.Set("If-None-Match", etag)
+		w2 := httptest.NewRecorder()
+		router.ServeHTTP(w2, r)
+		assert.Equal(t, http.StatusNotModified, w2.Code, test.target)
+		assert.Empty(t, w2.Body.String(), test.target)
+
+		r = httptest.NewRequest(http.MethodGet, test.target, nil)
+		r.Header.Set("If-None-Match", `"stale"`)
+		w3 := httptest.NewRecorder()
+		router.ServeHTTP(w3, r)
+		assert.Equal(t, http.StatusOK, w3.Code, test.target)
+	}
+
+	w := performRequest(http.MethodGet, "/swagger/swagger-ui-bundle.js", router)
+	assert.Equal(t, modTime.Format(http.TimeFormat), w.Header().Get("Last-Modified"))
+
+	r := httptest.NewRequest(http.MethodGet, "/swagger/swagger-ui-bundle.js", nil)
+	r.Header.Set("If-Modified-Since", modTime.Add(time.Hour).Format(http.TimeFormat))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+
+	w = performRequest(http.MethodGet, "/swagger/doc.json", router)
+	assert.Empty(t, w.Header().Get("Last-Modified"))
+}
+
+func TestDefaultCachePolicy(t *testing.T) {
+	cfg := newConfig()
+	assert.Equal(t, CachePolicy{}, cfg.CachePolicy)
+	assert.False(t, cfg.AssetsModTime.IsZero())
+
+	router := http.NewServeMux()
+	router.Handle("/swagger/", Handler())
+
+	w := performRequest(http.MethodGet, "/swagger/index.html", router)
+	assert.Empty(t, w.Header().Get("Cache-Control"))
+	assert.NotEmpty(t, w.Header().Get("ETag"))
+}
@@ -0,0 +1,69 @@
+// Command http-swagger exports the documentation of an API definition as a static site.
+//
+// Usage:
+//
+//	http-swagger export -spec docs/swagger.json -out site [-title title] [-renderer renderer] [-assets url] [-theme theme]
+//
+// The embedded ReDoc, RapiDoc and Elements assets are exported along with the page,
+// unless -assets sets the base URL they are loaded from, e.g. a CDN.
+//
+// Documents registered as swag instances are only known to the programs importing
+// them, which export them with httpSwagger.Export.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	httpSwagger "github.com/swaggo/http-swagger/v2"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "http-swagger:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 || args[0] != "export" {
+		return fmt.Errorf("usage: http-swagger export [flags]")
+	}
+
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	spec := flags.String("spec", "", "API definition `file`, JSON or YAML")
+	out := flags.String("out", "site", "output `directory`")
+	title := flags.String("title", "", "title of the index page")
+	renderer := flags.String("renderer", string(httpSwagger.SwaggerUIRenderer), "renderer: swagger-ui, redoc, rapidoc or elements")
+	assets := flags.String("assets", "", "base `URL` of the ReDoc, RapiDoc or Elements assets, the embedded ones when empty")
+	theme := flags.String("theme", "", "theme: light, dark or system")
+
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	if *spec == "" {
+		return fmt.Errorf("missing -spec")
+	}
+
+	opts := []func(*httpSwagger.Config){
+		httpSwagger.Provider(httpSwagger.FSProvider(os.DirFS(filepath.Dir(*spec)), filepath.Base(*spec))),
+		httpSwagger.Renderer(httpSwagger.RendererType(*renderer)),
+	}
+
+	if *title != "" {
+		opts = append(opts, httpSwagger.Title(*title))
+	}
+
+	if *assets != "" {
+		opts = append(opts, httpSwagger.RendererAssets(*assets))
+	}
+
+	if *theme != "" {
+		opts = append(opts, httpSwagger.Theme(httpSwagger.ThemeMode(*theme)))
+	}
+
+	return httpSwagger.Export(*out, opts...)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "swagger.yaml")
+	assert.NoError(t, os.WriteFile(spec, []byte("swagger: \"2.0\"\ninfo: {title: Pets, version: \"1\"}\npaths: {}\n"), 0o644))
+
+	out := filepath.Join(dir, "site")
+	assert.NoError(t, run([]string{"export", "-spec", spec, "-out", out, "-title", "Pets", "-theme", "dark"}))
+
+	index, err := os.ReadFile(filepath.Join(out, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(index), "<title>Pets</title>")
+	assert.Contains(t, string(index), `spec: {"swagger":"2.0","info":{"title":"Pets","version":"1"},"paths":{}},`)
+	assert.FileExists(t, filepath.Join(out, "doc.json"))
+	assert.FileExists(t, filepath.Join(out, "swagger-ui-bundle.js"))
+
+	out = filepath.Join(dir, "redoc")
+	assert.NoError(t, run([]string{"export", "-spec", spec, "-out", out, "-renderer", "redoc", "-assets", "https://cdn.example.com/redoc/"}))
+
+	index, err = os.ReadFile(filepath.Join(out, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(index), `<script src="https://cdn.example.com/redoc/redoc.standalone.js" crossorigin="anonymous"> </script>`)
+}
+
+func TestRunErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{
+		{nil, "usage: http-swagger export [flags]"},
+		{[]string{"serve"}, "usage: http-swagger export [flags]"},
+		{[]string{"export", "-unknown"}, "flag provided but not defined: -unknown"},
+		{[]string{"export"}, "missing -spec"},
+		{[]string{"export", "-spec", filepath.Join(dir, "missing.json"), "-renderer", "scalar"}, `invalid configuration: unsupported renderer "scalar"`},
+	} {
+		assert.EqualError(t, run(test.args), test.err, test.args)
+	}
+
+	assert.ErrorIs(t, run([]string{"export", "-spec", filepath.Join(dir, "missing.json"), "-out", dir}), fs.ErrNotExist)
+}
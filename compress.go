@@ -0,0 +1,229 @@
+package httpSwagger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andybalholm/brotli"
+)
+
+// Encoding is a content encoding responses can be compressed with.
+type Encoding string
+
+const (
+	Gzip   Encoding = "gzip"
+	Brotli Encoding = "br"
+)
+
+// Compression serves the Swagger UI assets and the API definitions compressed with the given
+// encodings, in order of preference, to clients that accept them. The assets are compressed
+// once when the handler is created and the definitions once each time their content changes.
+func Compression(encodings ...Encoding) func(*Config) {
+	return func(c *Config) {
+		c.Encodings = encodings
+	}
+}
+
+// compressibleExts holds the extensions of the assets served compressed.
+var compressibleExts = map[string]bool{
+	".css":  true,
+	".html": true,
+	".js":   true,
+	".json": true,
+}
+
+type variant struct {
+	content []byte
+	etag    string
+}
+
+func newVariant(content []byte) variant {
+	return variant{content: content, etag: strongETag(content)}
+}
+
+// encoded holds a resource along with its compressed variants.
+type encoded struct {
+	identity variant
+	// encodings lists the encodings of variants in order of preference.
+	encodings []Encoding
+	variants  map[Encoding]variant
+}
+
+func newEncoded(content []byte, encodings []Encoding) *encoded {
+	e := &encoded{
+		identity: newVariant(content),
+		variants: make(map[Encoding]variant, len(encodings)),
+	}
+
+	for _, enc := range encodings {
+		compressed, err := compress(content, enc)
+		// keep compressed variants only when they are worth it
+		if err != nil || len(compressed) >= len(content) {
+			continue
+		}
+
+		e.encodings = append(e.encodings, enc)
+		e.variants[enc] = newVariant(compressed)
+	}
+
+	return e
+}
+
+func compress(content []byte, enc Encoding) ([]byte, error) {
+	var (
+		buf bytes.Buffer
+		w   io.WriteCloser
+	)
+
+	switch enc {
+	case Gzip:
+		w, _ = gzip.NewWriterLevel(&buf, gzip.DefaultCompression)
+	case Brotli:
+		w = brotli.NewWriterLevel(&buf, brotli.DefaultCompression)
+	default:
+		return nil, errUnsupportedEncoding
+	}
+
+	if _, err := w.Write(content); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type encodingError string
+
+func (e encodingError) Error() string {
+	return string(e)
+}
+
+const errUnsupportedEncoding = encodingError("unsupported content encoding")
+
+// negotiateEncoding picks the encoding preferred by the Accept-Encoding header of r among
+// encodings, which wins ties in its order. It returns an empty Encoding for identity.
+func negotiateEncoding(r *http.Request, encodings []Encoding) Encoding {
+	accept := r.Header.Get("Accept-Encoding")
+	if accept == "" || len(encodings) == 0 {
+		return ""
+	}
+
+	qs := make(map[string]float64)
+
+	for _, part := range strings.Split(accept, ",") {
+		fields := strings.Split(part, ";")
+		coding := strings.ToLower(strings.TrimSpace(fields[0]))
+		q := 1.0
+
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					q = v
+				}
+			}
+		}
+
+		qs[coding] = q
+	}
+
+	var (
+		best  Encoding
+		bestQ float64
+	)
+
+	for _, enc := range encodings {
+		q, ok := qs[string(enc)]
+		if !ok {
+			q = qs["*"]
+		}
+
+		if q > bestQ {
+			best, bestQ = enc, q
+		}
+	}
+
+	return best
+}
+
+// serveEncoded writes the variant of e negotiated with the client, like serveBytes.
+func serveEncoded(w http.ResponseWriter, r *http.Request, name string, modTime time.Time, e *encoded, cacheControl string) {
+	v := e.identity
+
+	if len(e.encodings) > 0 {
+		w.Header().Add("Vary", "Accept-Encoding")
+	}
+
+	if enc := negotiateEncoding(r, e.encodings); enc != "" {
+		v = e.variants[enc]
+		w.Header().Set("Content-Encoding", string(enc))
+
+		// do not let http.ServeContent sniff the compressed content
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", mime.TypeByExtension(filepath.Ext(name)))
+		}
+	}
+
+	if cacheControl != "" {
+		w.Header().Set("Cache-Control", cacheControl)
+	}
+
+	w.Header().Set("ETag", v.etag)
+
+	http.ServeContent(w, r, name, modTime, bytes.NewReader(v.content))
+}
+
+// maxEncodingEntries bounds the number of contents cached by an encodingCache.
+const maxEncodingEntries = 256
+
+// encodingCache holds the compressed variants of the contents served for a key. The
+// contents are cached apart, as the documents filtered per audience or rewritten per
+// host differ for the same key.
+type encodingCache struct {
+	encodings []Encoding
+
+	mu      sync.Mutex
+	entries map[string]*encoded
+}
+
+func newEncodingCache(encodings []Encoding) *encodingCache {
+	return &encodingCache{
+		encodings: encodings,
+		entries:   make(map[string]*encoded),
+	}
+}
+
+func (c *encodingCache) get(key string, content []byte) *encoded {
+	key += "\x00" + strongETag(content)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		return e
+	}
+
+	if len(c.entries) >= maxEncodingEntries {
+		c.entries = make(map[string]*encoded)
+	}
+
+	e := newEncoded(content, c.encodings)
+	c.entries[key] = e
+
+	return e
+}
+
+func isCompressible(name string) bool {
+	return compressibleExts[filepath.Ext(name)]
+}
@@ -0,0 +1,136 @@
+package httpSwagger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggo/swag"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	encodings := []Encoding{Brotli, Gzip}
+
+	tests := []struct {
+		accept string
+		exp    Encoding
+	}{
+		{accept: "", exp: ""},
+		{accept: "identity", exp: ""},
+		{accept: "gzip", exp: Gzip},
+		{accept: "gzip, deflate, br", exp: Brotli},
+		{accept: "br;q=0.5, gzip", exp: Gzip},
+		{accept: "GZIP;q=0.1", exp: Gzip},
+		{accept: "*", exp: Brotli},
+		{accept: "*;q=0.5, br;q=0", exp: Gzip},
+		{accept: "gzip;q=0, br;q=0", exp: ""},
+	}
+
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept-Encoding", test.accept)
+		assert.Equal(t, test.exp, negotiateEncoding(r, encodings), test.accept)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	assert.Equal(t, Encoding(""), negotiateEncoding(r, nil))
+}
+
+func TestNewEncoded(t *testing.T) {
+	content := bytes.Repeat([]byte("swagger "), 1024)
+
+	e := newEncoded(content, []Encoding{Gzip, Brotli})
+	assert.Equal(t, []Encoding{Gzip, Brotli}, e.encodings)
+	assert.Equal(t, content, e.identity.content)
+	assert.NotEqual(t, e.identity.etag, e.variants[Gzip].etag)
+	assert.NotEqual(t, e.variants[Gzip].etag, e.variants[Brotli].etag)
+
+	gz, err := gzip.NewReader(bytes.NewReader(e.variants[Gzip].content))
+	assert.NoError(t, err)
+	out, err := io.ReadAll(gz)
+	assert.NoError(t, err)
+	assert.Equal(t, content, out)
+
+	out, err = io.ReadAll(brotli.NewReader(bytes.NewReader(e.variants[Brotli].content)))
+	assert.NoError(t, err)
+	assert.Equal(t, content, out)
+
+	// compression is skipped when it does not pay off
+	e = newEncoded([]byte("a"), []Encoding{Gzip, Brotli})
+	assert.Empty(t, e.encodings)
+}
+
+func TestEncodingCache(t *testing.T) {
+	c := newEncodingCache([]Encoding{Gzip})
+	content := bytes.Repeat([]byte("swagger "), 1024)
+
+	e1 := c.get("doc", content)
+	assert.Same(t, e1, c.get("doc", content))
+	assert.NotSame(t, e1, c.get("doc", append(content, '!')))
+	assert.NotSame(t, e1, c.get("other", content))
+
+	// the contents served for a key, e.g. per audience, are all kept
+	assert.Same(t, e1, c.get("doc", content))
+}
+
+func TestCompression(t *testing.T) {
+	swag.Register("compression", &mockedSwag{})
+
+	router := http.NewServeMux()
+	router.Handle("/swagger/", Handler(InstanceName("compression"), Compression(Brotli, Gzip)))
+
+	for _, target := range []string{"/swagger/swagger-ui-bundle.js", "/swagger/swagger-ui.css", "/swagger/doc.json"} {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		r.Header.Set("Accept-Encoding", "gzip")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code, target)
+		assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"), target)
+		assert.Contains(t, w.Header().Values("Vary"), "Accept-Encoding", target)
+
+		gz, err := gzip.NewReader(w.Body)
+		assert.NoError(t, err, target)
+		content, err := io.ReadAll(gz)
+		assert.NoError(t, err, target)
+
+		identity := performRequest(http.MethodGet, target, router)
+		assert.Equal(t, http.StatusOK, identity.Code, target)
+		assert.Empty(t, identity.Header().Get("Content-Encoding"), target)
+		assert.Equal(t, identity.Body.Bytes(), content, target)
+		assert.NotEqual(t, identity.Header().Get("ETag"), w.Header().Get("ETag"), target)
+
+		r = httptest.NewRequest(http.MethodGet, target, nil)
+		r.Header.Set("Accept-Encoding", "br, gzip")
+		r.Header.Set("If-None-Match", identity.Header().Get("ETag"))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code, target)
+		assert.Equal(t, "br", w.Header().Get("Content-Encoding"), target)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/swagger/swagger-ui-bundle.js", nil)
+	r.Header.Set("Accept-Encoding", "br")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	assert.Equal(t, "application/javascript", w.Header().Get("Content-Type"))
+
+	content, err := io.ReadAll(brotli.NewReader(w.Body))
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(string(content), "SwaggerUIBundle"))
+
+	// images are not compressed
+	r = httptest.NewRequest(http.MethodGet, "/swagger/favicon-16x16.png", nil)
+	r.Header.Set("Accept-Encoding", "br, gzip")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+}
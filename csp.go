@@ -0,0 +1,57 @@
+package httpSwagger
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"html/template"
+	"regexp"
+)
+
+// StrictCSP serves the index page with a Content-Security-Policy header which only
+// allows its own inline scripts and styles, including BeforeScript, AfterScript,
+// Stylesheets and the elements of HeadHTML, through a nonce generated for each request.
+func StrictCSP(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.StrictCSP = enabled
+	}
+}
+
+// spriteStyle is the inline style attribute of the SVG sprite of the index template.
+const spriteStyle = "position:absolute;width:0;height:0"
+
+// spriteStyleHash allows spriteStyle, as style attributes cannot carry a nonce.
+var spriteStyleHash = func() string {
+	sum := sha256.Sum256([]byte(spriteStyle))
+
+	return "'sha256-" + base64.StdEncoding.EncodeToString(sum[:]) + "'"
+}()
+
+func newNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// contentSecurityPolicy returns the policy of an index page rendered with nonce.
+func contentSecurityPolicy(nonce string) string {
+	return "script-src 'self' 'nonce-" + nonce + "'; " +
+		"style-src 'self' 'nonce-" + nonce + "' 'unsafe-hashes' " + spriteStyleHash + "; " +
+		"object-src 'none'; " +
+		"base-uri 'self'"
+}
+
+// nonceTags matches the opening tags of the elements allowed by a nonce.
+var nonceTags = regexp.MustCompile(`(?i)<(script|style|link)\b`)
+
+// addNonce adds nonce to the script, style and link elements of html.
+func addNonce(html template.HTML, nonce string) template.HTML {
+	if nonce == "" {
+		return html
+	}
+
+	return template.HTML(nonceTags.ReplaceAllString(string(html), `<$1 nonce="`+nonce+`"`))
+}
@@ -0,0 +1,67 @@
+package httpSwagger
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewNonce(t *testing.T) {
+	n1, err := newNonce()
+	assert.NoError(t, err)
+	assert.Len(t, n1, 22)
+
+	n2, err := newNonce()
+	assert.NoError(t, err)
+	assert.NotEqual(t, n1, n2)
+}
+
+func TestStrictCSP(t *testing.T) {
+	router := http.NewServeMux()
+	router.Handle("/swagger/", Handler(
+		StrictCSP(true),
+		BeforeScript(`const SomePlugin = (system) => ({});`),
+		AfterScript(`console.log("loaded");`),
+		Stylesheets("https://fonts.example.com/font.css"),
+	))
+	router.Handle("/head/", Handler(
+		StrictCSP(true),
+		HeadHTML(`<script src="https://cdn.example.com/a.js"></script><STYLE>h1{}</STYLE><meta name="robots" content="noindex">`),
+	))
+	router.Handle("/default/", Handler())
+
+	w1 := performRequest(http.MethodGet, "/swagger/index.html", router)
+	assert.Equal(t, http.StatusOK, w1.Code)
+
+	csp := w1.Header().Get("Content-Security-Policy")
+	matches := regexp.MustCompile(`^script-src 'self' 'nonce-([A-Za-z0-9_-]{22})'; `).FindStringSubmatch(csp)
+	if assert.Len(t, matches, 2, csp) {
+		nonce := matches[1]
+		assert.Equal(t, "script-src 'self' 'nonce-"+nonce+"'; "+
+			"style-src 'self' 'nonce-"+nonce+"' 'unsafe-hashes' 'sha256-ezdv1bOGcoOD7FKudKN0Y2Mb763O6qVtM8LT2mtanIU='; "+
+			"object-src 'none'; base-uri 'self'", csp)
+
+		body := w1.Body.String()
+		assert.Contains(t, body, `<style nonce="`+nonce+`">`)
+		assert.Contains(t, body, `<script nonce="`+nonce+`">`+"\nwindow.onload = function() {\n  const SomePlugin")
+		assert.Contains(t, body, `console.log("loaded");`)
+		assert.Contains(t, body, `style="`+spriteStyle+`"`)
+		assert.Contains(t, body, `<link rel="stylesheet" type="text/css" href="https://fonts.example.com/font.css" nonce="`+nonce+`" >`)
+		assert.Equal(t, 1, strings.Count(body, "<style"))
+		assert.Equal(t, 1, strings.Count(body, "<script>")+strings.Count(body, "<script nonce"))
+	}
+
+	w2 := performRequest(http.MethodGet, "/swagger/index.html", router)
+	assert.NotEqual(t, csp, w2.Header().Get("Content-Security-Policy"))
+
+	w3 := performRequest(http.MethodGet, "/head/index.html", router)
+	nonce := regexp.MustCompile(`'nonce-([^']+)'`).FindStringSubmatch(w3.Header().Get("Content-Security-Policy"))[1]
+	assert.Contains(t, w3.Body.String(), `<script nonce="`+nonce+`" src="https://cdn.example.com/a.js"></script><STYLE nonce="`+nonce+`">h1{}</STYLE><meta name="robots"`)
+
+	w3 = performRequest(http.MethodGet, "/default/index.html", router)
+	assert.Empty(t, w3.Header().Get("Content-Security-Policy"))
+	assert.NotContains(t, w3.Body.String(), "nonce")
+}
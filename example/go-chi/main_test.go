@@ -0,0 +1,37 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	httpSwagger "github.com/swaggo/http-swagger/v2"
+)
+
+func TestRoutes(t *testing.T) {
+	r := chi.NewRouter()
+	r.Get("/swagger/*", httpSwagger.Handler())
+	r.Route("/v1", func(r chi.Router) {
+		r.Get("/swagger/*", httpSwagger.Handler())
+	})
+
+	for _, root := range []string{"/swagger/", "/v1/swagger/"} {
+		for target, code := range map[string]int{
+			"index.html":                            http.StatusOK,
+			"index.html?urls.primaryName=a.b":       http.StatusOK,
+			"doc.json":                              http.StatusOK,
+			"swagger-ui.css":                        http.StatusOK,
+			"oauth2-redirect.html?state=0&code=1.2": http.StatusOK,
+			"":                                      http.StatusMovedPermanently,
+			"notfound":                              http.StatusNotFound,
+		} {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, root+target, nil))
+
+			if w.Code != code {
+				t.Errorf("GET %s: status %d, expected %d", root+target, w.Code, code)
+			}
+		}
+	}
+}
@@ -7,3 +7,5 @@ require (
 	github.com/swaggo/http-swagger/v2 v2.0.0
 	github.com/swaggo/swag v1.8.1
 )
+
+replace github.com/swaggo/http-swagger/v2 => ../../
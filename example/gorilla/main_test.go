@@ -0,0 +1,32 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	httpSwagger "github.com/swaggo/http-swagger/v2"
+)
+
+func TestRoutes(t *testing.T) {
+	r := mux.NewRouter()
+	r.PathPrefix("/swagger/").Handler(httpSwagger.Handler()).Methods(http.MethodGet)
+
+	for target, code := range map[string]int{
+		"index.html":                            http.StatusOK,
+		"index.html?urls.primaryName=a.b":       http.StatusOK,
+		"doc.json":                              http.StatusOK,
+		"swagger-ui.css":                        http.StatusOK,
+		"oauth2-redirect.html?state=0&code=1.2": http.StatusOK,
+		"":                                      http.StatusMovedPermanently,
+		"notfound":                              http.StatusNotFound,
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger/"+target, nil))
+
+		if w.Code != code {
+			t.Errorf("GET /swagger/%s: status %d, expected %d", target, w.Code, code)
+		}
+	}
+}
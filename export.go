@@ -0,0 +1,164 @@
+package httpSwagger
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Export writes to dir a static documentation site for hosts which cannot run Go:
+// the index page rendered with the configuration of opts, the Swagger UI assets and
+// the documents the handler would serve. The index page embeds the documents, so
+// that the site also works when opened from `file://`. Documents are read as by
+// callers without audience, see Audience. Hot reload is disabled.
+func Export(dir string, opts ...func(*Config)) error {
+	// the configuration is inlined as `file://` pages cannot fetch swagger-config.json
+	opts = append(append([]func(*Config){}, opts...), ExternalInitializer(false), func(c *Config) {
+		c.HotReload = 0
+	})
+
+	h, err := newValidHandler(opts...)
+	if err != nil {
+		return err
+	}
+
+	files, err := h.exportFiles()
+	if err != nil {
+		return err
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// exportFiles returns the files of the static site served by h, by name.
+func (h *handler) exportFiles() (map[string][]byte, error) {
+	files := make(map[string][]byte)
+
+	// assets are looked up in order, so the last ones are written first
+	for i := len(h.assets) - 1; i >= 0; i-- {
+		fsys := h.assets[i].fsys
+
+		err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+
+			content, err := fs.ReadFile(fsys, name)
+			files[name] = content
+
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("assets: %w", err)
+		}
+	}
+
+	config := *h.config
+
+	swaggerUI := config.Renderer == "" || config.Renderer == SwaggerUIRenderer
+
+	r, _ := http.NewRequest(http.MethodGet, "/index.html", nil)
+
+	docs := make(map[string][]byte, len(h.docs))
+
+	for name, read := range h.docs {
+		if !entitled(h.audiences[name], nil) {
+			continue
+		}
+
+		doc, err := read(r)
+		if err != nil {
+			return nil, fmt.Errorf("%s.json: %w", name, err)
+		}
+
+		docs[name+".json"] = doc
+		files[name+".json"] = doc
+	}
+
+	// `file://` pages cannot fetch the documents, which are embedded in the page
+	embed := func(url string) ([]byte, bool) {
+		doc, ok := docs[strings.TrimPrefix(url, "./")]
+
+		return doc, ok
+	}
+
+	if doc, ok := embed(config.URL); ok && swaggerUI && len(config.URLs) == 0 {
+		// Swagger UI renders the spec setting instead of fetching URL, which it shows
+		uiConfig := make(map[template.JS]template.JS, len(config.UIConfig)+1)
+		for k, v := range config.UIConfig {
+			uiConfig[k] = v
+		}
+
+		var spec bytes.Buffer
+		json.HTMLEscape(&spec, doc)
+
+		uiConfig["spec"] = template.JS(spec.String())
+		config.UIConfig = uiConfig
+	} else if swaggerUI {
+		// the definitions of the selector are fetched from `data:` URLs
+		config.URLs = append([]SpecURL(nil), config.URLs...)
+
+		for i, u := range config.URLs {
+			if doc, ok := embed(u.URL); ok {
+				config.URLs[i].URL = dataURL(doc)
+			}
+		}
+	}
+
+	data := IndexData{Config: &config, Validation: h.reportedValidation()}
+
+	// the other renderers render a single definition
+	if doc, ok := embed(data.SpecURL()); ok && !swaggerUI {
+		var spec bytes.Buffer
+		json.HTMLEscape(&spec, doc)
+
+		data.Spec = template.JS(spec.String())
+	}
+
+	var index bytes.Buffer
+	if err := h.index.Execute(&index, data); err != nil {
+		return nil, fmt.Errorf("index.html: %w", err)
+	}
+
+	files["index.html"] = index.Bytes()
+	files["swagger-initializer.js"] = h.initializer
+
+	swaggerConfig, err := h.config.swaggerConfig().MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("swagger-config.json: %w", err)
+	}
+
+	files["swagger-config.json"] = swaggerConfig
+
+	if validation := h.reportedValidation(); validation != nil {
+		if files["validation.json"], err = json.Marshal(validation); err != nil {
+			return nil, fmt.Errorf("validation.json: %w", err)
+		}
+	}
+
+	return files, nil
+}
+
+// dataURL returns the `data:` URL of the JSON document doc.
+func dataURL(doc []byte) string {
+	return "data:application/json;base64," + base64.StdEncoding.EncodeToString(doc)
+}
@@ -0,0 +1,91 @@
+package httpSwagger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggo/swag"
+)
+
+func TestExport(t *testing.T) {
+	swag.Register("export", swagDoc(`{"swagger":"2.0","info":{"title":"</script>"},"paths":{}}`))
+
+	dir := t.TempDir()
+
+	err := Export(dir,
+		InstanceName("export"),
+		Title("Pets"),
+		Files(os.DirFS("static")),
+		URLs(SpecURL{Name: "Orders", Provider: BytesProvider([]byte(`{"swagger":"2.0"}`), "application/json"), InstanceName: "orders"}),
+		HotReload(time.Second),
+	)
+	assert.NoError(t, err)
+
+	read := func(name string) string {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		assert.NoError(t, err)
+
+		return string(b)
+	}
+
+	index := read("index.html")
+	assert.Contains(t, index, "<title>Pets</title>")
+	assert.Contains(t, index, `urls: [{"name":"Orders","url":"data:application/json;base64,eyJzd2FnZ2VyIjoiMi4wIn0="}]`)
+	assert.NotContains(t, index, "spec:")
+	assert.NotContains(t, index, "hot-reload")
+	assert.Equal(t, `{"swagger":"2.0","info":{"title":"</script>"},"paths":{}}`, read("doc.json"))
+	assert.Equal(t, `{"swagger":"2.0"}`, read("orders.json"))
+	assert.Contains(t, read("swagger-config.json"), `"url":"doc.json"`)
+
+	for _, asset := range []string{"swagger-ui-bundle.js", "swagger-ui.css", "swagger-ui-dark.css", "hot-reload.js"} {
+		assert.FileExists(t, filepath.Join(dir, asset))
+	}
+
+	// the only document is embedded
+	dir = t.TempDir()
+
+	assert.NoError(t, Export(dir, InstanceName("export"), ExternalInitializer(true)))
+
+	index = read("index.html")
+	assert.Contains(t, index, `spec: {"swagger":"2.0","info":{"title":"\u003c/script\u003e"},"paths":{}},`)
+	assert.NotContains(t, index, "swagger-initializer.js")
+
+	// as are the documents rendered by the other renderers
+	for embedded, renderer := range map[string]func(*Config){
+		`Redoc.init({"swagger":"2.0","info":{"title":"\u003c/script\u003e"},"paths":{}}, {}`: ReDoc(ReDocConfig{}),
+		`.loadSpec({"swagger":"2.0","info":{"title":"\u003c/script\u003e"},"paths":{}})`:     RapiDoc(RapiDocConfig{}),
+		`<elements-api apiDescriptionDocument="{&#34;swagger&#34;:&#34;2.0&#34;,`:            Elements(ElementsConfig{}),
+	} {
+		dir = t.TempDir()
+
+		assert.NoError(t, Export(dir, InstanceName("export"), renderer, RendererAssets("https://cdn.example.com/")))
+		assert.Contains(t, read("index.html"), embedded)
+		assert.NotContains(t, read("index.html"), "doc.json")
+	}
+}
+
+func TestExportErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.EqualError(t, Export(dir, DocExpansion("all")), `invalid configuration: unsupported doc expansion "all"`)
+	assert.EqualError(t, Export(dir, InstanceName("export-missing")), `doc.json: no swag named "export-missing" was registered`)
+}
+
+func TestExportRendererAssets(t *testing.T) {
+	embedRendererAssets(t)
+
+	dir := t.TempDir()
+	assert.NoError(t, Export(dir, InstanceName("export"), ReDoc(ReDocConfig{})))
+
+	// the embedded assets are exported, so that the site works offline
+	index, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(index), `<script src="./redoc.standalone.js" integrity="sha384-`)
+
+	asset, err := os.ReadFile(filepath.Join(dir, "redoc.standalone.js"))
+	assert.NoError(t, err)
+	assert.Equal(t, "/* redoc.standalone.js */", string(asset))
+}
@@ -3,9 +3,11 @@ module github.com/swaggo/http-swagger/v2
 go 1.17
 
 require (
+	github.com/andybalholm/brotli v1.1.0
 	github.com/stretchr/testify v1.7.0
 	github.com/swaggo/files/v2 v2.0.2
 	github.com/swaggo/swag v1.8.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -21,5 +23,4 @@ require (
 	golang.org/x/sys v0.5.0 // indirect
 	golang.org/x/tools v0.1.12 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,199 @@
+package httpSwagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"html/template"
+	"sort"
+	"strings"
+	textTemplate "text/template"
+)
+
+// ExternalInitializer replaces the inline script of the index page with the
+// `swagger-initializer.js` script, which loads the configuration of Swagger UI
+// from `swagger-config.json`. That script is served whether enabled or not, in
+// place of the `swagger-initializer.js` file of the Swagger UI distribution.
+func ExternalInitializer(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.ExternalInitializer = enabled
+	}
+}
+
+// swaggerConfig returns the Swagger UI configuration published as `swagger-config.json`.
+// UIConfig entries which are not plain JSON, e.g. functions, are left to the initializer.
+func (c *Config) swaggerConfig() jsonObject {
+	var o jsonObject
+
+	_ = o.set("url", c.URL)
+
+	if len(c.URLs) > 0 {
+		_ = o.set("urls", c.URLs)
+
+		if c.PrimaryName != "" {
+			_ = o.set("urls.primaryName", c.PrimaryName)
+		}
+	}
+
+	_ = o.set("deepLinking", c.DeepLinking)
+	_ = o.set("docExpansion", c.DocExpansion)
+	_ = o.set("dom_id", "#"+c.DomID)
+	_ = o.set("persistAuthorization", c.PersistAuthorization)
+
+	if c.OAuth != nil && c.OAuth.RedirectURL != "" {
+		_ = o.set("oauth2RedirectUrl", c.OAuth.RedirectURL)
+	}
+
+	if c.ValidatorURL != "" {
+		_ = o.set("validatorUrl", c.ValidatorURL)
+	} else {
+		_ = o.set("validatorUrl", nil)
+	}
+
+	for _, setting := range c.uiSettings() {
+		o = append(o, jsonField{key: setting.Key, value: json.RawMessage(setting.Value)})
+	}
+
+	uiConfig := c.jsonUIConfig()
+
+	keys := make([]string, 0, len(uiConfig))
+	for k := range uiConfig {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		o = append(o, jsonField{key: k, value: uiConfig[k]})
+	}
+
+	_ = o.set("layout", c.Layout)
+	_ = o.set("defaultModelsExpandDepth", c.DefaultModelsExpandDepth)
+	_ = o.set("showExtensions", c.ShowExtensions)
+
+	return o
+}
+
+// jsonUIConfig returns the UIConfig entries whose values are plain JSON.
+func (c *Config) jsonUIConfig() map[string]json.RawMessage {
+	m := make(map[string]json.RawMessage)
+
+	for k, v := range c.UIConfig {
+		if key, ok := uiConfigKey(k); ok && json.Valid([]byte(v)) {
+			m[key] = json.RawMessage(v)
+		}
+	}
+
+	return m
+}
+
+// scriptUIConfig returns the UIConfig entries left to the initializer.
+func (c *Config) scriptUIConfig() map[template.JS]template.JS {
+	m := make(map[template.JS]template.JS)
+
+	for k, v := range c.UIConfig {
+		if _, ok := uiConfigKey(k); !ok || !json.Valid([]byte(v)) {
+			m[k] = v
+		}
+	}
+
+	return m
+}
+
+// uiConfigKey returns the property name of a UIConfig key, which is
+// either a JavaScript identifier or a quoted string.
+func uiConfigKey(k template.JS) (string, bool) {
+	key := strings.TrimSpace(string(k))
+	if strings.HasPrefix(key, `"`) {
+		var s string
+		if err := json.Unmarshal([]byte(key), &s); err != nil {
+			return "", false
+		}
+
+		return s, true
+	}
+
+	return key, isIdentifier(key)
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i, r := range s {
+		switch {
+		case r == '_' || r == '$' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z':
+		case i > 0 && '0' <= r && r <= '9':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// renderInitializer renders the `swagger-initializer.js` script of c.
+func renderInitializer(c *Config) ([]byte, error) {
+	tmpl, err := textTemplate.New("swagger-initializer.js").Funcs(textTemplate.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+
+			return string(b), err
+		},
+	}).Parse(initializerTempl)
+	if err != nil {
+		return nil, err
+	}
+
+	data := struct {
+		*Config
+		ScriptUIConfig map[template.JS]template.JS
+	}{
+		Config:         c,
+		ScriptUIConfig: c.scriptUIConfig(),
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+const initializerTempl = `window.onload = function() {
+  {{- if .BeforeScript}}
+  {{.BeforeScript}}
+  {{- end}}
+  // Build a system
+  const ui = SwaggerUIBundle({
+    configUrl: "swagger-config.json",
+    dom_id: {{json (print "#" .DomID)}},
+    {{- if and .OAuth (not .OAuth.RedirectURL)}}
+    oauth2RedirectUrl: new URL("oauth2-redirect.html", window.location.href).href,
+    {{- end}}
+    presets: [
+      SwaggerUIBundle.presets.apis,
+      SwaggerUIStandalonePreset
+    ],
+    plugins: [
+      SwaggerUIBundle.plugins.DownloadUrl
+      {{- range $plugin := .Plugins }},
+      {{$plugin}}
+      {{- end}}
+    ],
+    {{- range $k, $v := .ScriptUIConfig}}
+    {{$k}}: {{$v}},
+    {{- end}}
+    layout: {{json .Layout}}
+  })
+
+  window.ui = ui
+  {{- if .OAuth}}
+  ui.initOAuth({{json .OAuth}})
+  {{- end}}
+  {{- if .AfterScript}}
+  {{.AfterScript}}
+  {{- end}}
+}
+`
@@ -0,0 +1,115 @@
+package httpSwagger
+
+import (
+	"html/template"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUIConfigKey(t *testing.T) {
+	tests := []struct {
+		key template.JS
+		exp string
+		ok  bool
+	}{
+		{key: "filter", exp: "filter", ok: true},
+		{key: " $_filter1 ", exp: "$_filter1", ok: true},
+		{key: `"urls.primaryName"`, exp: "urls.primaryName", ok: true},
+		{key: "urls.primaryName", exp: "urls.primaryName", ok: false},
+		{key: "1filter", exp: "1filter", ok: false},
+		{key: `"broken`, exp: "", ok: false},
+	}
+
+	for _, test := range tests {
+		key, ok := uiConfigKey(test.key)
+		assert.Equal(t, test.exp, key, string(test.key))
+		assert.Equal(t, test.ok, ok, string(test.key))
+	}
+}
+
+func TestSwaggerConfig(t *testing.T) {
+	cfg := newConfig(
+		URLs(SpecURL{Name: "Users", InstanceName: "users"}),
+		PrimaryName("Users"),
+		OAuth(OAuthConfig{ClientID: "client", RedirectURL: "https://example.org/callback"}),
+		UIConfig(map[string]string{
+			"filter":     "true",
+			"onComplete": `() => { window.ui.setBasePath('v3'); }`,
+		}),
+	)
+
+	out, err := cfg.swaggerConfig().MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"url":"doc.json","urls":[{"name":"Users","url":"users.json"}],"urls.primaryName":"Users",`+
+		`"deepLinking":true,"docExpansion":"list","dom_id":"#swagger-ui","persistAuthorization":false,`+
+		`"oauth2RedirectUrl":"https://example.org/callback","validatorUrl":null,"filter":true,`+
+		`"layout":"StandaloneLayout","defaultModelsExpandDepth":1,"showExtensions":false}`, string(out))
+}
+
+func TestRenderInitializer(t *testing.T) {
+	out, err := renderInitializer(newConfig(
+		BeforeScript(`const SomePlugin = (system) => ({});`),
+		AfterScript(`console.log("loaded");`),
+		Plugins([]string{"SomePlugin"}),
+		OAuth(OAuthConfig{ClientID: "client"}),
+		UIConfig(map[string]string{
+			"filter":     "true",
+			"onComplete": `() => { window.ui.setBasePath('v3'); }`,
+		}),
+	))
+	assert.NoError(t, err)
+	assert.Equal(t, `window.onload = function() {
+  const SomePlugin = (system) => ({});
+  // Build a system
+  const ui = SwaggerUIBundle({
+    configUrl: "swagger-config.json",
+    dom_id: "#swagger-ui",
+    oauth2RedirectUrl: new URL("oauth2-redirect.html", window.location.href).href,
+    presets: [
+      SwaggerUIBundle.presets.apis,
+      SwaggerUIStandalonePreset
+    ],
+    plugins: [
+      SwaggerUIBundle.plugins.DownloadUrl,
+      SomePlugin
+    ],
+    onComplete: () => { window.ui.setBasePath('v3'); },
+    layout: "StandaloneLayout"
+  })
+
+  window.ui = ui
+  ui.initOAuth({"clientId":"client"})
+  console.log("loaded");
+}
+`, string(out))
+}
+
+func TestExternalInitializer(t *testing.T) {
+	router := http.NewServeMux()
+	router.Handle("/external/", Handler(ExternalInitializer(true), StrictCSP(true)))
+	router.Handle("/inline/", Handler())
+
+	w := performRequest(http.MethodGet, "/external/index.html", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "<script src=\"./swagger-initializer.js\"> </script>\n</body>")
+	assert.NotContains(t, w.Body.String(), "window.onload")
+
+	w = performRequest(http.MethodGet, "/inline/index.html", router)
+	assert.Contains(t, w.Body.String(), "window.onload")
+	assert.NotContains(t, w.Body.String(), "swagger-initializer.js")
+
+	for _, root := range []string{"/external/", "/inline/"} {
+		w = performRequest(http.MethodGet, root+"swagger-initializer.js", router)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/javascript", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), `configUrl: "swagger-config.json"`)
+		assert.NotEmpty(t, w.Header().Get("ETag"))
+
+		w = performRequest(http.MethodGet, root+"swagger-config.json", router)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), `"url":"doc.json"`)
+	}
+}
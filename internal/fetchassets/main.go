@@ -0,0 +1,79 @@
+// Command fetchassets downloads the pinned assets of the documentation renderers
+// embedded by this package. It is run by go generate.
+//
+// Usage:
+//
+//	fetchassets -dir static https://cdn.jsdelivr.net/npm/redoc@2.1.5/bundles/redoc.standalone.js
+//	fetchassets -dir schemas -name openapi-3.0.json https://spec.openapis.org/oas/3.0/schema/2021-09-28
+//
+// Each asset is written to the directory under the last element of its URL, or
+// the name set with -name for a single asset.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "fetchassets:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("fetchassets", flag.ContinueOnError)
+	dir := flags.String("dir", "", "output `directory`")
+	name := flags.String("name", "", "file `name` of the single asset, the last element of its URL by default")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *dir == "" || flags.NArg() == 0 || *name != "" && flags.NArg() > 1 {
+		return fmt.Errorf("usage: fetchassets -dir directory [-name name] url...")
+	}
+
+	if err := os.MkdirAll(*dir, 0o755); err != nil {
+		return err
+	}
+
+	for _, url := range flags.Args() {
+		file := *name
+		if file == "" {
+			file = path.Base(url)
+		}
+
+		if err := fetch(url, filepath.Join(*dir, file)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fetch writes the content of url to the file name.
+func fetch(url, name string) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: unexpected status %s", url, resp.Status)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s: %w", url, err)
+	}
+
+	return os.WriteFile(name, content, 0o644)
+}
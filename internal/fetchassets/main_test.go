@@ -0,0 +1,40 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/redoc@2.1.5/bundles/redoc.standalone.js" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		_, _ = w.Write([]byte("/* redoc */"))
+	}))
+	defer server.Close()
+
+	dir := filepath.Join(t.TempDir(), "redoc")
+	assert.NoError(t, run([]string{"-dir", dir, server.URL + "/redoc@2.1.5/bundles/redoc.standalone.js"}))
+
+	content, err := os.ReadFile(filepath.Join(dir, "redoc.standalone.js"))
+	assert.NoError(t, err)
+	assert.Equal(t, "/* redoc */", string(content))
+
+	assert.NoError(t, run([]string{"-dir", dir, "-name", "redoc.js", server.URL + "/redoc@2.1.5/bundles/redoc.standalone.js"}))
+	assert.FileExists(t, filepath.Join(dir, "redoc.js"))
+
+	assert.EqualError(t, run([]string{"-dir", dir, server.URL + "/missing.js"}), server.URL+"/missing.js: unexpected status 404 Not Found")
+
+	for _, args := range [][]string{{"-dir", dir}, {"-dir", dir, "-name", "a.js", server.URL + "/a.js", server.URL + "/b.js"}} {
+		assert.EqualError(t, run(args), "usage: fetchassets -dir directory [-name name] url...")
+	}
+}
@@ -0,0 +1,127 @@
+package httpSwagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+type jsonField struct {
+	key   string
+	value json.RawMessage
+}
+
+// jsonObject is a JSON object which keeps the order of its fields,
+// so documents can be edited without reordering them.
+type jsonObject []jsonField
+
+func parseJSONObject(data []byte) (jsonObject, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok != json.Delim('{') {
+		return nil, errors.New("JSON document is not an object")
+	}
+
+	var o jsonObject
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+
+		o = append(o, jsonField{key: tok.(string), value: value})
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+func (o jsonObject) get(key string) (json.RawMessage, bool) {
+	for _, f := range o {
+		if f.key == key {
+			return f.value, true
+		}
+	}
+
+	return nil, false
+}
+
+// decode decodes the value of key into v, reporting whether key is present.
+func (o jsonObject) decode(key string, v interface{}) (bool, error) {
+	raw, ok := o.get(key)
+	if !ok {
+		return false, nil
+	}
+
+	return true, json.Unmarshal(raw, v)
+}
+
+// set replaces the value of key, appending it when missing.
+func (o *jsonObject) set(key string, v interface{}) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	for i, f := range *o {
+		if f.key == key {
+			(*o)[i].value = value
+
+			return nil
+		}
+	}
+
+	*o = append(*o, jsonField{key: key, value: value})
+
+	return nil
+}
+
+func (o *jsonObject) delete(key string) {
+	for i, f := range *o {
+		if f.key == key {
+			*o = append((*o)[:i], (*o)[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, f := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(f.key)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(f.value)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
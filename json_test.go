@@ -0,0 +1,37 @@
+package httpSwagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONObject(t *testing.T) {
+	o, err := parseJSONObject([]byte(`{"swagger": "2.0", "info": {"title": "API"}, "paths": {}}`))
+	assert.NoError(t, err)
+
+	var info map[string]string
+	ok, err := o.decode("info", &info)
+	assert.True(t, ok)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"title": "API"}, info)
+
+	ok, err = o.decode("host", &info)
+	assert.False(t, ok)
+	assert.NoError(t, err)
+
+	assert.NoError(t, o.set("swagger", "2.0"))
+	assert.NoError(t, o.set("host", "example.org"))
+	o.delete("paths")
+	o.delete("missing")
+
+	out, err := o.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"swagger":"2.0","info":{"title": "API"},"host":"example.org"}`, string(out))
+
+	_, err = parseJSONObject([]byte(`[]`))
+	assert.Error(t, err)
+
+	_, err = parseJSONObject([]byte(`{"a":`))
+	assert.Error(t, err)
+}
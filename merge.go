@@ -0,0 +1,420 @@
+package httpSwagger
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// MergeSource is a document merged by Aggregate.
+type MergeSource struct {
+	// InstanceName is the swag instance merged when Provider is nil.
+	InstanceName string
+	// Provider replaces the swag registry lookup of InstanceName.
+	Provider DocProvider
+	// PathPrefix is prepended to the paths of the document, e.g. `/billing`.
+	PathPrefix string
+	// Namespace is prepended to the names of the definitions of the document and to
+	// the references to them, e.g. `billing.`. When empty, definitions defined by
+	// several documents must be identical.
+	Namespace string
+}
+
+func (s MergeSource) label(i int) string {
+	if s.Provider == nil && s.InstanceName != "" {
+		return fmt.Sprintf("instance %q", s.InstanceName)
+	}
+
+	return fmt.Sprintf("document %d", i+1)
+}
+
+// Aggregate serves at `doc.json` the merge of several documents, e.g. the swag
+// instances registered by the modules of a monolith, see MergeProvider.
+func Aggregate(sources ...MergeSource) func(*Config) {
+	return func(c *Config) {
+		if len(sources) == 0 {
+			c.errs = append(c.errs, fmt.Errorf("no document to aggregate"))
+
+			return
+		}
+
+		c.DocProvider = MergeProvider(sources...)
+	}
+}
+
+// MergeProvider provides the merge of the documents of sources, which must all be
+// Swagger 2.0 or all OpenAPI 3 documents. Their paths, tags, definitions and security
+// definitions are merged, and top-level fields such as info or host are taken from the
+// first document. The operations of the OpenAPI 3 documents whose servers differ from
+// those of the first one get them as operation servers. Conflicting paths or
+// definitions make reading fail.
+// The result is cached until the documents change.
+func MergeProvider(sources ...MergeSource) DocProvider {
+	var (
+		mu     sync.Mutex
+		source [sha256.Size]byte
+		result []byte
+	)
+
+	return DocProviderFunc(func(ctx context.Context) ([]byte, string, error) {
+		docs := make([][]byte, len(sources))
+		hash := sha256.New()
+
+		for i, s := range sources {
+			p := s.Provider
+			if p == nil {
+				p = SwagProvider(s.InstanceName)
+			}
+
+			doc, contentType, err := p.ReadDoc(ctx)
+			if err == nil && isYAML(contentType) {
+				doc, err = yamlToJSON(doc)
+			}
+
+			if err != nil {
+				return nil, "", fmt.Errorf("%s: %w", s.label(i), err)
+			}
+
+			docs[i] = doc
+			hash.Write(doc)
+			hash.Write([]byte{0})
+		}
+
+		var sum [sha256.Size]byte
+		copy(sum[:], hash.Sum(nil))
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if result != nil && sum == source {
+			return result, jsonContentType, nil
+		}
+
+		out, err := mergeDocs(sources, docs)
+		if err != nil {
+			return nil, "", err
+		}
+
+		source, result = sum, out
+
+		return out, jsonContentType, nil
+	})
+}
+
+// mergeDocs merges the JSON documents docs read from sources.
+func mergeDocs(sources []MergeSource, docs [][]byte) ([]byte, error) {
+	parsed := make([]Document, len(docs))
+
+	for i, doc := range docs {
+		dec := json.NewDecoder(bytes.NewReader(doc))
+		dec.UseNumber()
+
+		if err := dec.Decode(&parsed[i]); err != nil {
+			return nil, fmt.Errorf("%s: %w", sources[i].label(i), err)
+		}
+	}
+
+	base := parsed[0]
+	oas3 := base["openapi"] != nil
+
+	// differing base paths are moved to the paths
+	foldBasePath := false
+
+	for i, doc := range parsed {
+		if (doc["openapi"] != nil) != oas3 {
+			return nil, fmt.Errorf("%s: cannot merge Swagger 2.0 and OpenAPI 3 documents", sources[i].label(i))
+		}
+
+		if !oas3 && doc["basePath"] != base["basePath"] {
+			foldBasePath = true
+		}
+	}
+
+	merged := Document{}
+
+	for k, v := range base {
+		switch k {
+		case "paths", "tags", "components", "definitions", "parameters", "responses", "securityDefinitions":
+		default:
+			merged[k] = v
+		}
+	}
+
+	if foldBasePath {
+		delete(merged, "basePath")
+	}
+
+	for i, doc := range parsed {
+		m := &docMerger{label: sources[i].label(i), merged: merged, oas3: oas3}
+
+		doc.namespace(sources[i].Namespace, oas3)
+
+		if i > 0 {
+			doc.inheritSecurity(base["security"])
+
+			if oas3 {
+				doc.inheritServers(base["servers"])
+			}
+		}
+
+		prefix := strings.TrimSuffix(sources[i].PathPrefix, "/")
+		if basePath, _ := doc["basePath"].(string); foldBasePath {
+			prefix += strings.TrimSuffix(basePath, "/")
+		}
+
+		if err := m.mergePaths(doc.object("paths"), prefix); err != nil {
+			return nil, err
+		}
+
+		m.mergeTags(doc["tags"])
+
+		if err := m.mergeCollections(doc); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	if err := enc.Encode(merged); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// collections returns the names of the reusable objects of the document along with
+// whether they are namespaced: components of OpenAPI 3 documents, definitions of
+// Swagger 2.0 ones. Security schemes are shared by all documents.
+func (doc Document) collections(oas3 bool) map[string]bool {
+	if !oas3 {
+		return map[string]bool{"definitions": true, "parameters": true, "responses": true, "securityDefinitions": false}
+	}
+
+	components := doc.object("components")
+	collections := make(map[string]bool, len(components))
+
+	for name := range components {
+		if !isExtension(name) {
+			collections["components/"+name] = name != "securitySchemes"
+		}
+	}
+
+	return collections
+}
+
+// collection returns the object at the slash separated path, nil if there is none.
+func (doc Document) collection(path string) map[string]interface{} {
+	obj := map[string]interface{}(doc)
+
+	for _, key := range strings.Split(path, "/") {
+		obj, _ = obj[key].(map[string]interface{})
+	}
+
+	return obj
+}
+
+// namespace prepends ns to the names of the reusable objects of the document, and
+// updates the references to them.
+func (doc Document) namespace(ns string, oas3 bool) {
+	if ns == "" {
+		return
+	}
+
+	var prefixes []string
+
+	for path, namespaced := range doc.collections(oas3) {
+		collection := doc.collection(path)
+		if !namespaced || collection == nil {
+			continue
+		}
+
+		entries := make(map[string]interface{}, len(collection))
+		for name, v := range collection {
+			entries[name] = v
+			delete(collection, name)
+		}
+
+		for name, v := range entries {
+			collection[ns+name] = v
+		}
+
+		prefixes = append(prefixes, "#/"+path+"/")
+	}
+
+	rewriteRefs(map[string]interface{}(doc), prefixes, escapePointer(ns))
+}
+
+// rewriteRefs inserts ns after the prefixes of the local references of value.
+func rewriteRefs(value interface{}, prefixes []string, ns string) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := value["$ref"].(string); ok {
+			for _, prefix := range prefixes {
+				if strings.HasPrefix(ref, prefix) {
+					value["$ref"] = prefix + ns + ref[len(prefix):]
+
+					break
+				}
+			}
+		}
+
+		for _, child := range value {
+			rewriteRefs(child, prefixes, ns)
+		}
+	case []interface{}:
+		for _, child := range value {
+			rewriteRefs(child, prefixes, ns)
+		}
+	}
+}
+
+// inheritSecurity sets the global security requirements of the document on its
+// operations without any when they differ from the merged ones.
+func (doc Document) inheritSecurity(merged interface{}) {
+	security := doc["security"]
+	if reflect.DeepEqual(security, merged) {
+		return
+	}
+
+	if security == nil {
+		security = []interface{}{}
+	}
+
+	for _, raw := range doc.object("paths") {
+		item, _ := raw.(map[string]interface{})
+
+		for method, raw := range item {
+			if op, ok := raw.(map[string]interface{}); ok && isOperationMethod(method) && op["security"] == nil {
+				op["security"] = security
+			}
+		}
+	}
+}
+
+// inheritServers sets the servers of the OpenAPI 3 document on its operations without
+// any when they differ from the merged ones.
+func (doc Document) inheritServers(merged interface{}) {
+	// the default server of a document without servers
+	defaultServers := []interface{}{map[string]interface{}{"url": "/"}}
+
+	servers := doc["servers"]
+	if servers == nil {
+		servers = defaultServers
+	}
+
+	if merged == nil {
+		merged = defaultServers
+	}
+
+	if reflect.DeepEqual(servers, merged) {
+		return
+	}
+
+	for _, raw := range doc.object("paths") {
+		item, _ := raw.(map[string]interface{})
+		if item["servers"] != nil {
+			continue
+		}
+
+		for method, raw := range item {
+			if op, ok := raw.(map[string]interface{}); ok && isOperationMethod(method) && op["servers"] == nil {
+				op["servers"] = servers
+			}
+		}
+	}
+}
+
+// docMerger merges a document into merged.
+type docMerger struct {
+	label  string
+	merged Document
+	oas3   bool
+}
+
+func (m *docMerger) mergePaths(paths map[string]interface{}, prefix string) error {
+	mergedPaths := m.merged.object("paths")
+
+	for _, path := range sortedKeys(paths) {
+		item, ok := paths[path].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: path item %s is not an object", m.label, path)
+		}
+
+		existing, ok := mergedPaths[prefix+path].(map[string]interface{})
+		if !ok {
+			mergedPaths[prefix+path] = item
+
+			continue
+		}
+
+		for _, k := range sortedKeys(item) {
+			if v, ok := existing[k]; ok && !reflect.DeepEqual(v, item[k]) {
+				return fmt.Errorf("%s: conflicting %s of path %s", m.label, k, prefix+path)
+			}
+
+			existing[k] = item[k]
+		}
+	}
+
+	return nil
+}
+
+// mergeTags appends the tags not declared yet.
+func (m *docMerger) mergeTags(tags interface{}) {
+	list, _ := tags.([]interface{})
+	if len(list) == 0 {
+		return
+	}
+
+	merged, _ := m.merged["tags"].([]interface{})
+	declared := make(map[interface{}]bool, len(merged))
+
+	for _, tag := range merged {
+		if tag, ok := tag.(map[string]interface{}); ok {
+			declared[tag["name"]] = true
+		}
+	}
+
+	for _, tag := range list {
+		if obj, ok := tag.(map[string]interface{}); ok && !declared[obj["name"]] {
+			declared[obj["name"]] = true
+			merged = append(merged, tag)
+		}
+	}
+
+	m.merged["tags"] = merged
+}
+
+func (m *docMerger) mergeCollections(doc Document) error {
+	collections := doc.collections(m.oas3)
+
+	for _, path := range sortedKeys(collections) {
+		collection := doc.collection(path)
+		if len(collection) == 0 {
+			continue
+		}
+
+		merged := m.merged
+		for _, key := range strings.Split(path, "/") {
+			merged = merged.object(key)
+		}
+
+		for _, name := range sortedKeys(collection) {
+			if v, ok := merged[name]; ok && !reflect.DeepEqual(v, collection[name]) {
+				return fmt.Errorf("%s: conflicting %s %q", m.label, path, name)
+			}
+
+			merged[name] = collection[name]
+		}
+	}
+
+	return nil
+}
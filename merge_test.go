@@ -0,0 +1,176 @@
+package httpSwagger
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggo/swag"
+)
+
+// swagDoc is a swag document registered by the tests.
+type swagDoc string
+
+func (d swagDoc) ReadDoc() string {
+	return string(d)
+}
+
+func TestAggregate(t *testing.T) {
+	swag.Register("merge-pets", swagDoc(`{
+		"swagger": "2.0",
+		"info": {"title": "Shop", "version": "1.0"},
+		"basePath": "/api",
+		"tags": [{"name": "pets", "description": "Pets"}],
+		"securityDefinitions": {"key": {"type": "apiKey", "name": "X-Key", "in": "header"}},
+		"security": [{"key": []}],
+		"paths": {
+			"/pets": {"get": {"tags": ["pets"], "responses": {"200": {"schema": {"$ref": "#/definitions/Item"}}}}}
+		},
+		"definitions": {"Item": {"type": "object"}}
+	}`))
+	swag.Register("merge-orders", swagDoc(`{
+		"swagger": "2.0",
+		"info": {"title": "Orders", "version": "2.0"},
+		"basePath": "/orders/",
+		"tags": [{"name": "pets", "description": "Duplicate"}, {"name": "orders"}],
+		"securityDefinitions": {"key": {"type": "apiKey", "name": "X-Key", "in": "header"}},
+		"paths": {
+			"/": {"post": {"tags": ["orders"], "responses": {"200": {"schema": {"$ref": "#/definitions/Item"}}}}}
+		},
+		"definitions": {"Item": {"type": "string"}}
+	}`))
+
+	router := http.NewServeMux()
+	router.Handle("/swagger/", Handler(Aggregate(
+		MergeSource{InstanceName: "merge-pets"},
+		MergeSource{InstanceName: "merge-orders", Namespace: "orders.", PathPrefix: "/v1/"},
+	)))
+
+	w := performRequest(http.MethodGet, "/swagger/doc.json", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{
+		"swagger": "2.0",
+		"info": {"title": "Shop", "version": "1.0"},
+		"tags": [{"name": "pets", "description": "Pets"}, {"name": "orders"}],
+		"securityDefinitions": {"key": {"type": "apiKey", "name": "X-Key", "in": "header"}},
+		"security": [{"key": []}],
+		"paths": {
+			"/api/pets": {"get": {"tags": ["pets"], "responses": {"200": {"schema": {"$ref": "#/definitions/Item"}}}}},
+			"/v1/orders/": {"post": {
+				"tags": ["orders"],
+				"security": [],
+				"responses": {"200": {"schema": {"$ref": "#/definitions/orders.Item"}}}
+			}}
+		},
+		"definitions": {"Item": {"type": "object"}, "orders.Item": {"type": "string"}}
+	}`, w.Body.String())
+}
+
+func TestMergeProviderOpenAPI3(t *testing.T) {
+	users := BytesProvider([]byte(`
+openapi: 3.0.3
+info: {title: Users, version: "1"}
+servers: [{url: /}]
+paths:
+  /users:
+    get:
+      responses:
+        "200": {$ref: "#/components/responses/List"}
+components:
+  responses:
+    List: {description: users}
+`), "application/yaml")
+	groups := BytesProvider([]byte(`{
+		"openapi": "3.0.3",
+		"info": {"title": "Groups", "version": "1"},
+		"paths": {"/users": {"post": {"responses": {"200": {"$ref": "#/components/responses/List"}}}}},
+		"components": {"responses": {"List": {"description": "groups"}}}
+	}`), "application/json")
+
+	doc, contentType, err := MergeProvider(
+		MergeSource{Provider: users},
+		MergeSource{Provider: groups, Namespace: "groups/"},
+	).ReadDoc(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, jsonContentType, contentType)
+	assert.JSONEq(t, `{
+		"openapi": "3.0.3",
+		"info": {"title": "Users", "version": "1"},
+		"servers": [{"url": "/"}],
+		"paths": {"/users": {
+			"get": {"responses": {"200": {"$ref": "#/components/responses/List"}}},
+			"post": {"responses": {"200": {"$ref": "#/components/responses/groups~1List"}}}
+		}},
+		"components": {"responses": {"List": {"description": "users"}, "groups/List": {"description": "groups"}}}
+	}`, string(doc))
+}
+
+func TestMergeProviderServers(t *testing.T) {
+	openapi := func(doc string) MergeSource {
+		return MergeSource{Provider: BytesProvider([]byte(doc), "application/json")}
+	}
+
+	doc, _, err := MergeProvider(
+		openapi(`{"openapi": "3.0.3", "paths": {"/users": {"get": {}}}}`),
+		openapi(`{"openapi": "3.0.3", "servers": [{"url": "/"}], "paths": {"/groups": {"get": {}}}}`),
+		openapi(`{"openapi": "3.0.3", "servers": [{"url": "https://billing.example.com/v1"}], "paths": {
+			"/invoices": {"get": {}, "post": {"servers": [{"url": "https://upload.example.com"}]}, "parameters": []},
+			"/reports": {"servers": [{"url": "https://reports.example.com"}], "get": {}}
+		}}`),
+	).ReadDoc(context.Background())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"openapi": "3.0.3",
+		"paths": {
+			"/users": {"get": {}},
+			"/groups": {"get": {}},
+			"/invoices": {
+				"get": {"servers": [{"url": "https://billing.example.com/v1"}]},
+				"post": {"servers": [{"url": "https://upload.example.com"}]},
+				"parameters": []
+			},
+			"/reports": {"servers": [{"url": "https://reports.example.com"}], "get": {}}
+		}
+	}`, string(doc))
+}
+
+func TestMergeProviderConflicts(t *testing.T) {
+	swagger := func(doc string) MergeSource {
+		return MergeSource{Provider: BytesProvider([]byte(doc), "application/json")}
+	}
+
+	pets := swagger(`{"swagger": "2.0", "paths": {"/pets": {"get": {}}}, "definitions": {"Pet": {"type": "object"}}}`)
+
+	for _, tc := range []struct {
+		source MergeSource
+		err    string
+	}{
+		{
+			swagger(`{"swagger": "2.0", "paths": {"/pets": {"get": {"summary": "other"}}}}`),
+			"document 2: conflicting get of path /pets",
+		},
+		{
+			swagger(`{"swagger": "2.0", "definitions": {"Pet": {"type": "string"}}}`),
+			`document 2: conflicting definitions "Pet"`,
+		},
+		{
+			swagger(`{"openapi": "3.0.0"}`),
+			"document 2: cannot merge Swagger 2.0 and OpenAPI 3 documents",
+		},
+		{
+			MergeSource{InstanceName: "merge-missing"},
+			`instance "merge-missing": no swag named "merge-missing" was registered`,
+		},
+	} {
+		_, _, err := MergeProvider(pets, tc.source).ReadDoc(context.Background())
+		assert.EqualError(t, err, tc.err)
+	}
+
+	// identical definitions are merged
+	_, _, err := MergeProvider(pets, pets).ReadDoc(context.Background())
+	assert.NoError(t, err)
+
+	_, err = NewHandler(Aggregate())
+	assert.EqualError(t, err, "invalid configuration: no document to aggregate")
+}
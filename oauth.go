@@ -0,0 +1,32 @@
+package httpSwagger
+
+// OAuthConfig holds the OAuth2 settings passed to Swagger UI `initOAuth`.
+type OAuthConfig struct {
+	// ClientID is the default client id.
+	ClientID string `json:"clientId,omitempty"`
+	// Realm is added to the authorization and token URLs.
+	Realm string `json:"realm,omitempty"`
+	// AppName is the application name displayed in the authorization popup.
+	AppName string `json:"appName,omitempty"`
+	// Scopes are the scopes selected by default.
+	Scopes []string `json:"scopes,omitempty"`
+	// ScopeSeparator separates scopes in the authorization request. Defaults to a space.
+	ScopeSeparator string `json:"scopeSeparator,omitempty"`
+	// AdditionalQueryStringParams are added to the authorization and token URLs.
+	AdditionalQueryStringParams map[string]string `json:"additionalQueryStringParams,omitempty"`
+	// UseBasicAuthenticationWithAccessCodeGrant sends the client credentials of the
+	// access code grant with HTTP basic authentication.
+	UseBasicAuthenticationWithAccessCodeGrant bool `json:"useBasicAuthenticationWithAccessCodeGrant,omitempty"`
+	// UsePKCEWithAuthorizationCodeGrant enables Proof Key for Code Exchange for the authorization code grant.
+	UsePKCEWithAuthorizationCodeGrant bool `json:"usePkceWithAuthorizationCodeGrant,omitempty"`
+	// RedirectURL is the OAuth2 redirect URL. Defaults to the `oauth2-redirect.html`
+	// page served next to the index page.
+	RedirectURL string `json:"-"`
+}
+
+// OAuth configures the OAuth2 authorization of Swagger UI.
+func OAuth(config OAuthConfig) func(*Config) {
+	return func(c *Config) {
+		c.OAuth = &config
+	}
+}
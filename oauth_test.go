@@ -0,0 +1,54 @@
+package httpSwagger
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOAuth(t *testing.T) {
+	cfg := newConfig()
+	assert.Nil(t, cfg.OAuth)
+
+	cfg = newConfig(OAuth(OAuthConfig{ClientID: "client", Scopes: []string{"read", "write"}}))
+	assert.Equal(t, &OAuthConfig{ClientID: "client", Scopes: []string{"read", "write"}}, cfg.OAuth)
+}
+
+func TestOAuthIndex(t *testing.T) {
+	router := http.NewServeMux()
+	router.Handle("/swagger/", Handler(OAuth(OAuthConfig{
+		ClientID:                          "client",
+		Realm:                             "realm",
+		AppName:                           "app",
+		Scopes:                            []string{"read", "write"},
+		AdditionalQueryStringParams:       map[string]string{"audience": "api"},
+		UsePKCEWithAuthorizationCodeGrant: true,
+	})))
+	router.Handle("/custom/", Handler(OAuth(OAuthConfig{
+		ClientID:    "client",
+		RedirectURL: "https://example.org/callback",
+		UseBasicAuthenticationWithAccessCodeGrant: true,
+	})))
+	router.Handle("/none/", Handler())
+
+	w := performRequest(http.MethodGet, "/swagger/index.html", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "\n    oauth2RedirectUrl: new URL(\"oauth2-redirect.html\", window.location.href).href,\n")
+	assert.Contains(t, w.Body.String(), "\n  ui.initOAuth("+
+		`{"clientId":"client","realm":"realm","appName":"app","scopes":["read","write"],"additionalQueryStringParams":{"audience":"api"},"usePkceWithAuthorizationCodeGrant":true}`+
+		")\n")
+
+	w = performRequest(http.MethodGet, "/custom/index.html", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "\n    oauth2RedirectUrl: \"https:\\/\\/example.org\\/callback\",\n")
+	assert.Contains(t, w.Body.String(), "\n  ui.initOAuth("+`{"clientId":"client","useBasicAuthenticationWithAccessCodeGrant":true}`+")\n")
+
+	w = performRequest(http.MethodGet, "/none/index.html", router)
+	assert.NotContains(t, w.Body.String(), "oauth2RedirectUrl")
+	assert.NotContains(t, w.Body.String(), "initOAuth")
+
+	w = performRequest(http.MethodGet, "/swagger/oauth2-redirect.html?state=0&code=1", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "oauth2")
+}
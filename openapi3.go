@@ -0,0 +1,788 @@
+package httpSwagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// OpenAPIVersion is the OpenAPI 3 version a Swagger 2.0 document is converted to.
+type OpenAPIVersion string
+
+const (
+	OpenAPI30 OpenAPIVersion = "3.0.3"
+	OpenAPI31 OpenAPIVersion = "3.1.0"
+)
+
+var operationMethods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+// convertToOpenAPI3 converts a Swagger 2.0 document to the given OpenAPI 3 version.
+func convertToOpenAPI3(doc []byte, version OpenAPIVersion) ([]byte, error) {
+	var src map[string]interface{}
+
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+
+	if err := dec.Decode(&src); err != nil {
+		return nil, err
+	}
+
+	if v, _ := src["swagger"].(string); v != "2.0" {
+		return nil, errors.New("only Swagger 2.0 documents can be converted to OpenAPI 3")
+	}
+
+	c := &openAPIConverter{
+		version:    version,
+		src:        src,
+		bodyParams: make(map[string]bool),
+		dropped:    make(map[string]bool),
+	}
+
+	dst := c.convert()
+	if err := c.checkSecurity(); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(dst)
+}
+
+type openAPIConverter struct {
+	version OpenAPIVersion
+	src     map[string]interface{}
+	// bodyParams holds the names of global body parameters, which become request bodies.
+	bodyParams map[string]bool
+	// dropped holds the names of the security definitions without OpenAPI 3 equivalent.
+	dropped map[string]bool
+}
+
+func (c *openAPIConverter) convert() map[string]interface{} {
+	dst := map[string]interface{}{
+		"openapi": string(c.version),
+		"servers": c.servers(),
+		"paths":   map[string]interface{}{},
+	}
+
+	for k, v := range c.src {
+		switch {
+		case k == "info" || k == "tags" || k == "externalDocs" || k == "security" || isExtension(k):
+			dst[k] = v
+		}
+	}
+
+	components := map[string]interface{}{}
+
+	if params, ok := c.src["parameters"].(map[string]interface{}); ok {
+		parameters := map[string]interface{}{}
+		requestBodies := map[string]interface{}{}
+
+		for name, p := range params {
+			param, _ := p.(map[string]interface{})
+			if param["in"] == "body" {
+				c.bodyParams[name] = true
+			}
+		}
+
+		for name, p := range params {
+			param, _ := p.(map[string]interface{})
+			if param["in"] == "body" {
+				requestBodies[name] = c.requestBody([]map[string]interface{}{param}, c.globalList("consumes"))
+			} else {
+				parameters[name] = c.parameter(param)
+			}
+		}
+
+		setIfNotEmpty(components, "parameters", parameters)
+		setIfNotEmpty(components, "requestBodies", requestBodies)
+	}
+
+	if defs, ok := c.src["definitions"].(map[string]interface{}); ok {
+		schemas := make(map[string]interface{}, len(defs))
+		for name, schema := range defs {
+			schemas[name] = c.schema(schema)
+		}
+
+		components["schemas"] = schemas
+	}
+
+	if resps, ok := c.src["responses"].(map[string]interface{}); ok {
+		responses := make(map[string]interface{}, len(resps))
+		for name, resp := range resps {
+			responses[name] = c.response(resp, c.globalList("produces"))
+		}
+
+		components["responses"] = responses
+	}
+
+	if defs, ok := c.src["securityDefinitions"].(map[string]interface{}); ok {
+		schemes := make(map[string]interface{}, len(defs))
+		for name, def := range defs {
+			// OAuth2 schemes without a known flow have no OpenAPI 3 equivalent
+			if scheme, ok := securityScheme(def); ok {
+				schemes[name] = scheme
+			} else {
+				c.dropped[name] = true
+			}
+		}
+
+		setIfNotEmpty(components, "securitySchemes", schemes)
+	}
+
+	setIfNotEmpty(dst, "components", components)
+
+	if paths, ok := c.src["paths"].(map[string]interface{}); ok {
+		dstPaths := make(map[string]interface{}, len(paths))
+		for path, item := range paths {
+			dstPaths[path] = c.pathItem(item)
+		}
+
+		dst["paths"] = dstPaths
+	}
+
+	return dst
+}
+
+// checkSecurity reports the security requirements of the document which reference
+// a dropped security definition, as removing them would change who may call the API.
+func (c *openAPIConverter) checkSecurity() error {
+	check := func(where string, v interface{}) error {
+		reqs, _ := v.([]interface{})
+		for _, req := range reqs {
+			schemes, _ := req.(map[string]interface{})
+			for _, name := range sortedKeys(schemes) {
+				if c.dropped[name] {
+					return fmt.Errorf("%s: OAuth2 security definition %q has no known flow", where, name)
+				}
+			}
+		}
+
+		return nil
+	}
+
+	if len(c.dropped) == 0 {
+		return nil
+	}
+
+	if err := check("security", c.src["security"]); err != nil {
+		return err
+	}
+
+	paths, _ := c.src["paths"].(map[string]interface{})
+	for _, path := range sortedKeys(paths) {
+		item, _ := paths[path].(map[string]interface{})
+		for _, method := range operationMethods {
+			if op, ok := item[method].(map[string]interface{}); ok {
+				if err := check(method+" "+path, op["security"]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *openAPIConverter) servers() []interface{} {
+	return c.serversFor(stringList(c.src["schemes"]))
+}
+
+// serversFor returns the servers of the document for the given schemes.
+func (c *openAPIConverter) serversFor(schemes []string) []interface{} {
+	basePath, _ := c.src["basePath"].(string)
+	if basePath == "" {
+		basePath = "/"
+	}
+
+	host, _ := c.src["host"].(string)
+	if host == "" {
+		return []interface{}{map[string]interface{}{"url": basePath}}
+	}
+
+	if len(schemes) == 0 {
+		schemes = []string{"https"}
+	}
+
+	servers := make([]interface{}, 0, len(schemes))
+	for _, scheme := range schemes {
+		servers = append(servers, map[string]interface{}{"url": scheme + "://" + host + strings.TrimSuffix(basePath, "/")})
+	}
+
+	return servers
+}
+
+func (c *openAPIConverter) pathItem(v interface{}) interface{} {
+	item, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+
+	dst := make(map[string]interface{}, len(item))
+
+	// body and formData parameters of the path become the request body of its
+	// operations, path items having none in OpenAPI 3
+	var bodyParams []interface{}
+
+	for k, v := range item {
+		switch {
+		case k == "$ref":
+			dst[k] = v
+		case k == "parameters":
+			var params []interface{}
+
+			list, _ := v.([]interface{})
+			for _, p := range list {
+				if c.isBodyParam(p) {
+					bodyParams = append(bodyParams, p)
+				} else {
+					params = append(params, p)
+				}
+			}
+
+			params, _ = c.parameters(params, nil)
+			setIfNotEmpty(dst, k, params)
+		case isExtension(k):
+			dst[k] = v
+		}
+	}
+
+	for _, method := range operationMethods {
+		if op, ok := item[method].(map[string]interface{}); ok {
+			dst[method] = c.operation(op, bodyParams)
+		}
+	}
+
+	return dst
+}
+
+// isBodyParam reports whether the Swagger 2.0 parameter p is part of the request body.
+func (c *openAPIConverter) isBodyParam(p interface{}) bool {
+	param, _ := p.(map[string]interface{})
+	if ref, ok := param["$ref"].(string); ok {
+		return c.bodyParams[strings.TrimPrefix(ref, "#/parameters/")]
+	}
+
+	return param["in"] == "body" || param["in"] == "formData"
+}
+
+// operation converts a Swagger 2.0 operation, inheriting the body and formData
+// parameters of its path it does not override.
+func (c *openAPIConverter) operation(op map[string]interface{}, pathBodyParams []interface{}) map[string]interface{} {
+	dst := make(map[string]interface{}, len(op))
+
+	for k, v := range op {
+		switch k {
+		case "tags", "summary", "description", "externalDocs", "operationId", "deprecated", "security":
+			dst[k] = v
+		default:
+			if isExtension(k) {
+				dst[k] = v
+			}
+		}
+	}
+
+	consumes := c.list(op, "consumes")
+	produces := c.list(op, "produces")
+
+	params, body := c.parameters(c.inheritBodyParams(op["parameters"], pathBodyParams), consumes)
+	setIfNotEmpty(dst, "parameters", params)
+
+	if body != nil {
+		dst["requestBody"] = body
+	}
+
+	// schemes of the operation become servers, which only differ with a host
+	if _, ok := op["schemes"]; ok && c.src["host"] != nil {
+		dst["servers"] = c.serversFor(stringList(op["schemes"]))
+	}
+
+	if resps, ok := op["responses"].(map[string]interface{}); ok {
+		responses := make(map[string]interface{}, len(resps))
+		for code, resp := range resps {
+			responses[code] = c.response(resp, produces)
+		}
+
+		dst["responses"] = responses
+	}
+
+	return dst
+}
+
+// inheritBodyParams appends to the parameters of an operation the body and formData
+// parameters of its path, unless it has a body or a form field of the same name.
+func (c *openAPIConverter) inheritBodyParams(v interface{}, pathBodyParams []interface{}) []interface{} {
+	list, _ := v.([]interface{})
+	if len(pathBodyParams) == 0 {
+		return list
+	}
+
+	var hasBody bool
+
+	fields := make(map[interface{}]bool)
+
+	for _, p := range list {
+		param, _ := p.(map[string]interface{})
+
+		switch {
+		case !c.isBodyParam(p):
+		case param["in"] == "formData":
+			fields[param["name"]] = true
+		default:
+			hasBody = true
+		}
+	}
+
+	params := append([]interface{}(nil), list...)
+
+	for _, p := range pathBodyParams {
+		param, _ := p.(map[string]interface{})
+
+		if !hasBody && !(param["in"] == "formData" && fields[param["name"]]) {
+			params = append(params, p)
+		}
+	}
+
+	return params
+}
+
+// parameters converts a Swagger 2.0 parameter list into OpenAPI 3 parameters
+// and, for body and formData parameters, a request body.
+func (c *openAPIConverter) parameters(v interface{}, consumes []string) ([]interface{}, interface{}) {
+	list, _ := v.([]interface{})
+
+	var (
+		params []interface{}
+		body   interface{}
+		form   []map[string]interface{}
+	)
+
+	for _, p := range list {
+		param, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if ref, ok := param["$ref"].(string); ok {
+			name := strings.TrimPrefix(ref, "#/parameters/")
+			if c.bodyParams[name] {
+				body = map[string]interface{}{"$ref": "#/components/requestBodies/" + name}
+			} else {
+				params = append(params, map[string]interface{}{"$ref": convertRef(ref)})
+			}
+
+			continue
+		}
+
+		switch param["in"] {
+		case "body":
+			body = c.requestBody([]map[string]interface{}{param}, consumes)
+		case "formData":
+			form = append(form, param)
+		default:
+			params = append(params, c.parameter(param))
+		}
+	}
+
+	if len(form) > 0 {
+		body = c.requestBody(form, consumes)
+	}
+
+	return params, body
+}
+
+func (c *openAPIConverter) parameter(param map[string]interface{}) map[string]interface{} {
+	dst := map[string]interface{}{}
+	schema := map[string]interface{}{}
+
+	for k, v := range param {
+		switch k {
+		case "name", "in", "description", "required", "allowEmptyValue":
+			dst[k] = v
+		case "collectionFormat":
+			switch v {
+			case "csv":
+				dst["style"], dst["explode"] = "form", false
+			case "ssv":
+				dst["style"] = "spaceDelimited"
+			case "pipes":
+				dst["style"] = "pipeDelimited"
+			case "multi":
+				dst["style"], dst["explode"] = "form", true
+			}
+		case "x-example":
+			dst["example"] = v
+		default:
+			if isExtension(k) {
+				dst[k] = v
+			} else {
+				schema[k] = v
+			}
+		}
+	}
+
+	if param["in"] == "path" {
+		dst["required"] = true
+	}
+
+	if param["in"] == "header" || param["in"] == "path" {
+		if dst["style"] == "form" {
+			dst["style"] = "simple"
+		}
+	}
+
+	dst["schema"] = c.schema(schema)
+
+	return dst
+}
+
+func (c *openAPIConverter) requestBody(params []map[string]interface{}, consumes []string) map[string]interface{} {
+	dst := map[string]interface{}{}
+
+	if len(params) == 1 && params[0]["in"] == "body" {
+		param := params[0]
+		if v, ok := param["description"]; ok {
+			dst["description"] = v
+		}
+
+		if v, ok := param["required"]; ok {
+			dst["required"] = v
+		}
+
+		for k, v := range param {
+			if isExtension(k) {
+				dst[k] = v
+			}
+		}
+
+		dst["content"] = content(consumes, c.schema(param["schema"]), nil)
+
+		return dst
+	}
+
+	properties := map[string]interface{}{}
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+
+	var (
+		required []interface{}
+		hasFile  bool
+	)
+
+	for _, param := range params {
+		name, _ := param["name"].(string)
+		prop := c.parameter(param)["schema"].(map[string]interface{})
+
+		if v, ok := param["description"]; ok {
+			prop["description"] = v
+		}
+
+		if param["type"] == "file" {
+			hasFile = true
+		}
+
+		if req, _ := param["required"].(bool); req {
+			required = append(required, name)
+			dst["required"] = true
+		}
+
+		properties[name] = prop
+	}
+
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	var formTypes []string
+
+	for _, ct := range consumes {
+		if ct == "multipart/form-data" || ct == "application/x-www-form-urlencoded" {
+			formTypes = append(formTypes, ct)
+		}
+	}
+
+	if len(formTypes) == 0 {
+		formTypes = []string{"application/x-www-form-urlencoded"}
+		if hasFile {
+			formTypes = []string{"multipart/form-data"}
+		}
+	}
+
+	dst["content"] = content(formTypes, schema, nil)
+
+	return dst
+}
+
+func (c *openAPIConverter) response(v interface{}, produces []string) interface{} {
+	resp, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+
+	if ref, ok := resp["$ref"].(string); ok {
+		return map[string]interface{}{"$ref": convertRef(ref)}
+	}
+
+	dst := map[string]interface{}{"description": ""}
+
+	for k, v := range resp {
+		switch {
+		case k == "description" || isExtension(k):
+			dst[k] = v
+		case k == "headers":
+			headers, _ := v.(map[string]interface{})
+			dstHeaders := make(map[string]interface{}, len(headers))
+
+			for name, h := range headers {
+				header, _ := h.(map[string]interface{})
+				dstHeader := map[string]interface{}{}
+				schema := map[string]interface{}{}
+
+				for k, v := range header {
+					if k == "description" || isExtension(k) {
+						dstHeader[k] = v
+					} else {
+						schema[k] = v
+					}
+				}
+
+				dstHeader["schema"] = c.schema(schema)
+				dstHeaders[name] = dstHeader
+			}
+
+			dst[k] = dstHeaders
+		}
+	}
+
+	if schema, ok := resp["schema"]; ok {
+		examples, _ := resp["examples"].(map[string]interface{})
+		dst["content"] = content(produces, c.schema(schema), examples)
+	}
+
+	return dst
+}
+
+// schema converts a Swagger 2.0 schema object to its OpenAPI 3 equivalent.
+func (c *openAPIConverter) schema(v interface{}) interface{} {
+	switch s := v.(type) {
+	case []interface{}:
+		dst := make([]interface{}, len(s))
+		for i, item := range s {
+			dst[i] = c.schema(item)
+		}
+
+		return dst
+	case map[string]interface{}:
+		dst := make(map[string]interface{}, len(s))
+
+		for k, v := range s {
+			switch k {
+			case "$ref":
+				if ref, ok := v.(string); ok {
+					dst[k] = convertRef(ref)
+				} else {
+					dst[k] = v
+				}
+			case "properties", "definitions", "patternProperties":
+				props, _ := v.(map[string]interface{})
+				dstProps := make(map[string]interface{}, len(props))
+
+				for name, prop := range props {
+					dstProps[name] = c.schema(prop)
+				}
+
+				dst[k] = dstProps
+			case "items", "additionalProperties", "allOf", "anyOf", "oneOf", "not":
+				dst[k] = c.schema(v)
+			case "discriminator":
+				if name, ok := v.(string); ok {
+					dst[k] = map[string]interface{}{"propertyName": name}
+				} else {
+					dst[k] = v
+				}
+			case "x-nullable":
+				if nullable, _ := v.(bool); !nullable {
+					dst[k] = v
+				}
+			case "x-example":
+				dst["example"] = v
+			default:
+				dst[k] = v
+			}
+		}
+
+		if dst["type"] == "file" {
+			dst["type"], dst["format"] = "string", "binary"
+		}
+
+		if c.version != OpenAPI30 {
+			exclusiveBound(dst, "exclusiveMinimum", "minimum")
+			exclusiveBound(dst, "exclusiveMaximum", "maximum")
+		}
+
+		if nullable, _ := s["x-nullable"].(bool); nullable {
+			typ, typed := dst["type"].(string)
+
+			switch {
+			case c.version == OpenAPI30:
+				dst["nullable"] = true
+			case typed:
+				dst["type"] = []interface{}{typ, "null"}
+			default:
+				// references and untyped schemas cannot hold a null type
+				return map[string]interface{}{"anyOf": []interface{}{dst, map[string]interface{}{"type": "null"}}}
+			}
+		}
+
+		return dst
+	default:
+		return v
+	}
+}
+
+// exclusiveBound converts the Swagger 2.0 boolean exclusive bound of schema to the
+// numeric one of OpenAPI 3.1, which replaces the bound.
+func exclusiveBound(schema map[string]interface{}, exclusive, bound string) {
+	switch v, _ := schema[exclusive].(bool); {
+	case v && schema[bound] != nil:
+		schema[exclusive] = schema[bound]
+		delete(schema, bound)
+	case schema[exclusive] != nil && !isNumber(schema[exclusive]):
+		delete(schema, exclusive)
+	}
+}
+
+func isNumber(v interface{}) bool {
+	_, ok := v.(json.Number)
+
+	return ok
+}
+
+func (c *openAPIConverter) list(op map[string]interface{}, key string) []string {
+	if _, ok := op[key]; ok {
+		return stringList(op[key])
+	}
+
+	return c.globalList(key)
+}
+
+func (c *openAPIConverter) globalList(key string) []string {
+	if l := stringList(c.src[key]); len(l) > 0 {
+		return l
+	}
+
+	return []string{"application/json"}
+}
+
+// securityScheme converts a Swagger 2.0 security definition, reporting false when
+// it has no OpenAPI 3 equivalent.
+func securityScheme(v interface{}) (interface{}, bool) {
+	def, ok := v.(map[string]interface{})
+	if !ok {
+		return v, true
+	}
+
+	dst := map[string]interface{}{}
+
+	for k, v := range def {
+		if k == "description" || isExtension(k) {
+			dst[k] = v
+		}
+	}
+
+	switch def["type"] {
+	case "basic":
+		dst["type"], dst["scheme"] = "http", "basic"
+	case "apiKey":
+		dst["type"], dst["name"], dst["in"] = "apiKey", def["name"], def["in"]
+	case "oauth2":
+		flow := map[string]interface{}{"scopes": map[string]interface{}{}}
+		if scopes, ok := def["scopes"]; ok {
+			flow["scopes"] = scopes
+		}
+
+		if v, ok := def["authorizationUrl"]; ok {
+			flow["authorizationUrl"] = v
+		}
+
+		if v, ok := def["tokenUrl"]; ok {
+			flow["tokenUrl"] = v
+		}
+
+		flowName, ok := map[interface{}]string{
+			"implicit":    "implicit",
+			"password":    "password",
+			"application": "clientCredentials",
+			"accessCode":  "authorizationCode",
+		}[def["flow"]]
+		if !ok {
+			return nil, false
+		}
+
+		dst["type"] = "oauth2"
+		dst["flows"] = map[string]interface{}{flowName: flow}
+	default:
+		dst["type"] = def["type"]
+	}
+
+	return dst, true
+}
+
+func content(mediaTypes []string, schema interface{}, examples map[string]interface{}) map[string]interface{} {
+	dst := make(map[string]interface{}, len(mediaTypes))
+
+	for _, mt := range mediaTypes {
+		media := map[string]interface{}{"schema": schema}
+		if example, ok := examples[mt]; ok {
+			media["example"] = example
+		}
+
+		dst[mt] = media
+	}
+
+	return dst
+}
+
+func convertRef(ref string) string {
+	for from, to := range map[string]string{
+		"#/definitions/": "#/components/schemas/",
+		"#/parameters/":  "#/components/parameters/",
+		"#/responses/":   "#/components/responses/",
+	} {
+		if strings.HasPrefix(ref, from) {
+			return to + strings.TrimPrefix(ref, from)
+		}
+	}
+
+	return ref
+}
+
+func stringList(v interface{}) []string {
+	list, _ := v.([]interface{})
+
+	strs := make([]string, 0, len(list))
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			strs = append(strs, s)
+		}
+	}
+
+	return strs
+}
+
+func setIfNotEmpty(m map[string]interface{}, key string, v interface{}) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if len(val) == 0 {
+			return
+		}
+	case []interface{}:
+		if len(val) == 0 {
+			return
+		}
+	}
+
+	m[key] = v
+}
+
+func isExtension(key string) bool {
+	return strings.HasPrefix(key, "x-")
+}
@@ -0,0 +1,348 @@
+package httpSwagger
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggo/swag"
+)
+
+const petstoreDoc = `{
+    "swagger": "2.0",
+    "info": {"title": "Petstore", "version": "1.0"},
+    "host": "petstore.swagger.io",
+    "basePath": "/v2",
+    "schemes": ["https", "http"],
+    "consumes": ["application/json"],
+    "produces": ["application/json", "application/xml"],
+    "tags": [{"name": "pet"}],
+    "x-logo": "logo.png",
+    "paths": {
+        "/pet/{petId}": {
+            "parameters": [{"name": "petId", "in": "path", "required": true, "type": "integer", "format": "int64"}],
+            "get": {
+                "tags": ["pet"],
+                "operationId": "getPetById",
+                "parameters": [
+                    {"name": "tags", "in": "query", "type": "array", "items": {"type": "string"}, "collectionFormat": "multi"},
+                    {"$ref": "#/parameters/traceId"}
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successful operation",
+                        "schema": {"$ref": "#/definitions/Pet"},
+                        "headers": {"X-Rate-Limit": {"type": "integer", "description": "calls per hour"}}
+                    },
+                    "404": {"$ref": "#/responses/NotFound"}
+                },
+                "security": [{"api_key": []}]
+            },
+            "post": {
+                "operationId": "updatePetWithForm",
+                "consumes": ["multipart/form-data"],
+                "parameters": [
+                    {"name": "name", "in": "formData", "type": "string", "required": true, "description": "pet name"},
+                    {"name": "file", "in": "formData", "type": "file"}
+                ],
+                "responses": {"200": {"description": "ok"}}
+            },
+            "put": {
+                "operationId": "updatePet",
+                "parameters": [{"$ref": "#/parameters/pet"}],
+                "responses": {"200": {"description": "ok"}}
+            }
+        },
+        "/pet": {
+            "post": {
+                "operationId": "addPet",
+                "parameters": [{"name": "body", "in": "body", "required": true, "description": "new pet", "schema": {"$ref": "#/definitions/Pet"}}],
+                "responses": {"201": {"description": "created"}}
+            }
+        }
+    },
+    "parameters": {
+        "traceId": {"name": "X-Trace-Id", "in": "header", "type": "string"},
+        "pet": {"name": "pet", "in": "body", "schema": {"$ref": "#/definitions/Pet"}}
+    },
+    "responses": {
+        "NotFound": {"description": "not found"}
+    },
+    "definitions": {
+        "Pet": {
+            "type": "object",
+            "discriminator": "kind",
+            "properties": {
+                "kind": {"type": "string"},
+                "name": {"type": "string", "x-nullable": true},
+                "tags": {"type": "array", "items": {"$ref": "#/definitions/Tag"}}
+            }
+        },
+        "Tag": {"type": "object", "properties": {"name": {"type": "string"}}}
+    },
+    "securityDefinitions": {
+        "api_key": {"type": "apiKey", "name": "api_key", "in": "header"},
+        "basic": {"type": "basic"},
+        "petstore_auth": {
+            "type": "oauth2",
+            "flow": "accessCode",
+            "authorizationUrl": "https://petstore.swagger.io/oauth/authorize",
+            "tokenUrl": "https://petstore.swagger.io/oauth/token",
+            "scopes": {"write:pets": "modify pets"}
+        }
+    }
+}`
+
+func TestConvertToOpenAPI3(t *testing.T) {
+	out, err := convertToOpenAPI3([]byte(petstoreDoc), OpenAPI30)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+    "openapi": "3.0.3",
+    "info": {"title": "Petstore", "version": "1.0"},
+    "servers": [{"url": "https://petstore.swagger.io/v2"}, {"url": "http://petstore.swagger.io/v2"}],
+    "tags": [{"name": "pet"}],
+    "x-logo": "logo.png",
+    "paths": {
+        "/pet/{petId}": {
+            "parameters": [{"name": "petId", "in": "path", "required": true, "schema": {"type": "integer", "format": "int64"}}],
+            "get": {
+                "tags": ["pet"],
+                "operationId": "getPetById",
+                "parameters": [
+                    {"name": "tags", "in": "query", "style": "form", "explode": true, "schema": {"type": "array", "items": {"type": "string"}}},
+                    {"$ref": "#/components/parameters/traceId"}
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successful operation",
+                        "headers": {"X-Rate-Limit": {"description": "calls per hour", "schema": {"type": "integer"}}},
+                        "content": {
+                            "application/json": {"schema": {"$ref": "#/components/schemas/Pet"}},
+                            "application/xml": {"schema": {"$ref": "#/components/schemas/Pet"}}
+                        }
+                    },
+                    "404": {"$ref": "#/components/responses/NotFound"}
+                },
+                "security": [{"api_key": []}]
+            },
+            "post": {
+                "operationId": "updatePetWithForm",
+                "requestBody": {
+                    "required": true,
+                    "content": {
+                        "multipart/form-data": {
+                            "schema": {
+                                "type": "object",
+                                "required": ["name"],
+                                "properties": {
+                                    "name": {"type": "string", "description": "pet name"},
+                                    "file": {"type": "string", "format": "binary"}
+                                }
+                            }
+                        }
+                    }
+                },
+                "responses": {"200": {"description": "ok"}}
+            },
+            "put": {
+                "operationId": "updatePet",
+                "requestBody": {"$ref": "#/components/requestBodies/pet"},
+                "responses": {"200": {"description": "ok"}}
+            }
+        },
+        "/pet": {
+            "post": {
+                "operationId": "addPet",
+                "requestBody": {
+                    "description": "new pet",
+                    "required": true,
+                    "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}
+                },
+                "responses": {"201": {"description": "created"}}
+            }
+        }
+    },
+    "components": {
+        "parameters": {
+            "traceId": {"name": "X-Trace-Id", "in": "header", "schema": {"type": "string"}}
+        },
+        "requestBodies": {
+            "pet": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}
+        },
+        "responses": {
+            "NotFound": {"description": "not found"}
+        },
+        "schemas": {
+            "Pet": {
+                "type": "object",
+                "discriminator": {"propertyName": "kind"},
+                "properties": {
+                    "kind": {"type": "string"},
+                    "name": {"type": "string", "nullable": true},
+                    "tags": {"type": "array", "items": {"$ref": "#/components/schemas/Tag"}}
+                }
+            },
+            "Tag": {"type": "object", "properties": {"name": {"type": "string"}}}
+        },
+        "securitySchemes": {
+            "api_key": {"type": "apiKey", "name": "api_key", "in": "header"},
+            "basic": {"type": "http", "scheme": "basic"},
+            "petstore_auth": {
+                "type": "oauth2",
+                "flows": {
+                    "authorizationCode": {
+                        "authorizationUrl": "https://petstore.swagger.io/oauth/authorize",
+                        "tokenUrl": "https://petstore.swagger.io/oauth/token",
+                        "scopes": {"write:pets": "modify pets"}
+                    }
+                }
+            }
+        }
+    }
+}`, string(out))
+
+	out, err = convertToOpenAPI3([]byte(`{"swagger":"2.0","basePath":"/api","paths":{},"definitions":{"A":{"type":"string","x-nullable":true}}}`), OpenAPI31)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+    "openapi": "3.1.0",
+    "servers": [{"url": "/api"}],
+    "paths": {},
+    "components": {"schemas": {"A": {"type": ["string", "null"]}}}
+}`, string(out))
+
+	_, err = convertToOpenAPI3([]byte(`{"openapi":"3.0.0"}`), OpenAPI30)
+	assert.Error(t, err)
+
+	_, err = convertToOpenAPI3([]byte(`{`), OpenAPI30)
+	assert.Error(t, err)
+}
+
+func TestConvertToOpenAPI31(t *testing.T) {
+	out, err := convertToOpenAPI3([]byte(`{
+    "swagger": "2.0",
+    "host": "example.com",
+    "schemes": ["https"],
+    "paths": {
+        "/pets": {"get": {"schemes": ["http"], "responses": {}}}
+    },
+    "definitions": {
+        "Count": {"type": "integer", "minimum": 1, "exclusiveMinimum": true, "maximum": 9, "exclusiveMaximum": false},
+        "Pet": {"$ref": "#/definitions/Count", "x-nullable": true},
+        "Any": {"x-nullable": true}
+    },
+    "securityDefinitions": {
+        "broken": {"type": "oauth2", "scopes": {}},
+        "key": {"type": "apiKey", "name": "key", "in": "query"}
+    }
+}`), OpenAPI31)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+    "openapi": "3.1.0",
+    "servers": [{"url": "https://example.com"}],
+    "paths": {
+        "/pets": {"get": {"servers": [{"url": "http://example.com"}], "responses": {}}}
+    },
+    "components": {
+        "schemas": {
+            "Count": {"type": "integer", "exclusiveMinimum": 1, "maximum": 9},
+            "Pet": {"anyOf": [{"$ref": "#/components/schemas/Count"}, {"type": "null"}]},
+            "Any": {"anyOf": [{}, {"type": "null"}]}
+        },
+        "securitySchemes": {"key": {"type": "apiKey", "name": "key", "in": "query"}}
+    }
+}`, string(out))
+
+	// boolean bounds are valid in OpenAPI 3.0
+	out, err = convertToOpenAPI3([]byte(`{"swagger":"2.0","paths":{},"definitions":{"A":{"minimum":1,"exclusiveMinimum":true}}}`), OpenAPI30)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), `"A":{"exclusiveMinimum":true,"minimum":1}`)
+}
+
+func TestConvertPathBodyParameters(t *testing.T) {
+	out, err := convertToOpenAPI3([]byte(`{
+    "swagger": "2.0",
+    "paths": {
+        "/pets": {
+            "parameters": [
+                {"name": "id", "in": "query", "type": "string"},
+                {"name": "pet", "in": "body", "schema": {"type": "object"}}
+            ],
+            "put": {"responses": {}},
+            "post": {"parameters": [{"name": "other", "in": "body", "schema": {"type": "string"}}], "responses": {}}
+        },
+        "/files": {
+            "parameters": [
+                {"name": "name", "in": "formData", "type": "string"},
+                {"name": "file", "in": "formData", "type": "file"}
+            ],
+            "post": {"parameters": [{"name": "name", "in": "formData", "type": "integer"}], "responses": {}}
+        }
+    }
+}`), OpenAPI30)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+    "openapi": "3.0.3",
+    "servers": [{"url": "/"}],
+    "paths": {
+        "/pets": {
+            "parameters": [{"name": "id", "in": "query", "schema": {"type": "string"}}],
+            "put": {
+                "requestBody": {"content": {"application/json": {"schema": {"type": "object"}}}},
+                "responses": {}
+            },
+            "post": {
+                "requestBody": {"content": {"application/json": {"schema": {"type": "string"}}}},
+                "responses": {}
+            }
+        },
+        "/files": {
+            "post": {
+                "requestBody": {"content": {"multipart/form-data": {"schema": {
+                    "type": "object",
+                    "properties": {
+                        "name": {"type": "integer"},
+                        "file": {"type": "string", "format": "binary"}
+                    }
+                }}}},
+                "responses": {}
+            }
+        }
+    }
+}`, string(out))
+}
+
+func TestConvertDroppedSecurityScheme(t *testing.T) {
+	doc := `{
+    "swagger": "2.0",
+    "paths": {"/pets": {"get": {"security": [{"broken": []}], "responses": {}}}},
+    "securityDefinitions": {"broken": {"type": "oauth2", "flow": "unknown", "scopes": {}}}
+}`
+
+	_, err := convertToOpenAPI3([]byte(doc), OpenAPI30)
+	assert.EqualError(t, err, `get /pets: OAuth2 security definition "broken" has no known flow`)
+
+	_, err = convertToOpenAPI3([]byte(strings.Replace(doc, `"security": [{"broken": []}], `, "", 1)), OpenAPI30)
+	assert.NoError(t, err)
+}
+
+func TestOpenAPI3(t *testing.T) {
+	swag.Register("openapi", &mockedSwag{})
+
+	router := http.NewServeMux()
+	router.Handle("/swagger/", Handler(InstanceName("openapi"), OpenAPI3(OpenAPI31)))
+
+	w1 := performRequest(http.MethodGet, "/swagger/openapi.json", router)
+	assert.Equal(t, http.StatusOK, w1.Code)
+	assert.Equal(t, "application/json; charset=utf-8", w1.Header().Get("Content-Type"))
+	assert.Contains(t, w1.Body.String(), `"openapi":"3.1.0"`)
+	assert.Contains(t, w1.Body.String(), `"servers":[{"url":"https://petstore.swagger.io/v2"}]`)
+
+	w2 := performRequest(http.MethodGet, "/swagger/openapi.yaml", router)
+	assert.Equal(t, http.StatusOK, w2.Code)
+	assert.Contains(t, w2.Body.String(), "openapi: 3.1.0\n")
+
+	router = http.NewServeMux()
+	router.Handle("/swagger/", Handler(InstanceName("openapi")))
+	assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/swagger/openapi.json", router).Code)
+}
@@ -0,0 +1,390 @@
+package httpSwagger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Service is an upstream service whose document is served by a Portal.
+type Service struct {
+	// Name is the label of the service in the definition selector.
+	Name string
+	// URL points to the document of the service.
+	URL string
+	// HealthURL is checked along with the document when not empty. The service is
+	// unavailable when it responds with a status other than 2xx.
+	HealthURL string
+	// PathPrefix and Namespace apply to the document when merged, see MergeSource.
+	PathPrefix string
+	Namespace  string
+}
+
+// PortalConfig configures a Portal.
+type PortalConfig struct {
+	Services []Service
+	// Remote configures the requests to the services. Its TTL and StaleWhileRevalidate
+	// are unused, the documents being fetched again at every refresh.
+	Remote RemoteConfig
+	// Interval is the duration between two refreshes. Defaults to 1 minute.
+	Interval time.Duration
+	// Merge serves the documents of the available services merged at `doc.json`,
+	// instead of listing every service in the definition selector.
+	Merge bool
+}
+
+// ServiceStatus is the state of a service of a Portal.
+type ServiceStatus struct {
+	Name      string    `json:"name"`
+	URL       string    `json:"url"`
+	Available bool      `json:"available"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+const defaultPortalInterval = time.Minute
+
+// Portal serves the documents of upstream services, which it refreshes in the
+// background while Run runs. Services failing their last refresh are listed as
+// unavailable on the Swagger UI index page, with their last fetched document still
+// served in the definition selector, or left out of the merged document. The status of the services is served
+// at `status.json`. All routes answer HEAD requests.
+type Portal struct {
+	interval time.Duration
+	merge    bool
+	opts     []func(*Config)
+	services []*portalService
+
+	// base serves the services, which handler serves along with their availability
+	base *handler
+
+	mu      sync.RWMutex
+	handler *handler
+	// listed holds the availability of the services handler and merged were set for
+	listed []bool
+	// merged provides the merge of the available services with Merge
+	merged DocProvider
+}
+
+// portalService is a service of a Portal along with its state.
+type portalService struct {
+	Service
+	remote *remoteProvider
+
+	mu     sync.Mutex
+	status ServiceStatus
+}
+
+// NewPortal returns a Portal serving the services of config, with the index page
+// configured by opts. The documents are fetched by Refresh and Run, after the handler
+// is created, so Validation and HotReload, which read them from the handler, are
+// not supported.
+func NewPortal(config PortalConfig, opts ...func(*Config)) (*Portal, error) {
+	errs := config.Remote.validate()
+
+	if len(config.Services) == 0 {
+		errs = append(errs, errors.New("no service"))
+	}
+
+	if config.Interval < 0 {
+		errs = append(errs, fmt.Errorf("negative portal interval %v", config.Interval))
+	}
+
+	p := &Portal{
+		interval: config.Interval,
+		merge:    config.Merge,
+		opts:     opts,
+	}
+
+	if p.interval == 0 {
+		p.interval = defaultPortalInterval
+	}
+
+	handlerConfig := newConfig(opts...)
+
+	if handlerConfig.Validation != "" {
+		errs = append(errs, errors.New("Validation is not supported by Portal"))
+	}
+
+	if handlerConfig.HotReload != 0 {
+		errs = append(errs, errors.New("HotReload is not supported by Portal"))
+	}
+
+	// unless merged, services are served at their name, which must not be the one of
+	// a route of the handler or status.json
+	// the services by name of the document they are served at
+	served := make(map[string]string, len(config.Services))
+
+	for i, s := range config.Services {
+		name := proxyName(s.Name, i)
+		first, ok := served[name]
+
+		switch {
+		case s.Name == "":
+			errs = append(errs, fmt.Errorf("service %d has no name", i+1))
+		case config.Merge:
+		case name == "status" || handlerConfig.reservedName(name):
+			errs = append(errs, fmt.Errorf("service %q: name %q is reserved", s.Name, name))
+		case ok:
+			errs = append(errs, fmt.Errorf("service %q: document %s.json already served for service %q", s.Name, name, first))
+		default:
+			served[name] = s.Name
+		}
+
+		if !isRemoteURL(s.URL) {
+			errs = append(errs, fmt.Errorf("service %q: invalid URL %q", s.Name, s.URL))
+		}
+
+		if s.HealthURL != "" && !isRemoteURL(s.HealthURL) {
+			errs = append(errs, fmt.Errorf("service %q: invalid health URL %q", s.Name, s.HealthURL))
+		}
+
+		p.services = append(p.services, &portalService{
+			Service: s,
+			remote:  RemoteProvider(s.URL, config.Remote).(*remoteProvider),
+			status:  ServiceStatus{Name: s.Name, URL: s.URL},
+		})
+	}
+
+	if err := newConfigError(errs); err != nil {
+		return nil, err
+	}
+
+	h, err := newValidHandler(p.options()...)
+	if err != nil {
+		return nil, err
+	}
+
+	p.base = h
+	p.setAvailability(p.availability())
+
+	return p, nil
+}
+
+// Run refreshes the services every interval until ctx is done, starting right
+// away, and returns the error of ctx.
+func (p *Portal) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.Refresh(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh fetches the documents of the services and checks their health.
+func (p *Portal) Refresh(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for _, s := range p.services {
+		wg.Add(1)
+
+		go func(s *portalService) {
+			defer wg.Done()
+
+			s.check(ctx)
+		}(s)
+	}
+
+	wg.Wait()
+
+	listed := p.availability()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !reflect.DeepEqual(listed, p.listed) {
+		p.setAvailability(listed)
+	}
+}
+
+// Status returns the status of the services.
+func (p *Portal) Status() []ServiceStatus {
+	statuses := make([]ServiceStatus, len(p.services))
+
+	for i, s := range p.services {
+		s.mu.Lock()
+		statuses[i] = s.status
+		s.mu.Unlock()
+	}
+
+	return statuses
+}
+
+// ServeHTTP implements http.Handler.
+func (p *Portal) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.RLock()
+	h := p.handler
+	p.mu.RUnlock()
+
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		if path, ok := h.config.resolvePath(r); ok && path == "status.json" {
+			body, _ := json.Marshal(p.Status())
+			serveBytes(w, r, path, time.Time{}, body, h.config.CachePolicy.Index)
+
+			return
+		}
+	}
+
+	h.ServeHTTP(w, r)
+}
+
+// availability reports whether each service is available, services not checked
+// yet being considered available.
+func (p *Portal) availability() []bool {
+	available := make([]bool, len(p.services))
+
+	for i, s := range p.services {
+		s.mu.Lock()
+		available[i] = s.status.Available || s.status.CheckedAt.IsZero()
+		s.mu.Unlock()
+	}
+
+	return available
+}
+
+// options returns the options of the handler serving the services.
+func (p *Portal) options() []func(*Config) {
+	opts := append([]func(*Config){}, p.opts...)
+
+	if p.merge {
+		return append(opts, Provider(DocProviderFunc(func(ctx context.Context) ([]byte, string, error) {
+			p.mu.RLock()
+			merged := p.merged
+			p.mu.RUnlock()
+
+			if merged == nil {
+				return nil, "", errors.New("services not merged yet")
+			}
+
+			return merged.ReadDoc(ctx)
+		})))
+	}
+
+	urls := make([]SpecURL, len(p.services))
+	for i, s := range p.services {
+		urls[i] = SpecURL{Name: s.Name, InstanceName: proxyName(s.Name, i), Provider: s.provider()}
+	}
+
+	return append(opts, URLs(urls...))
+}
+
+// setAvailability updates the merged services given their availability, and the
+// unavailable services shown on the index page. The documents are served by base
+// whatever their availability.
+func (p *Portal) setAvailability(available []bool) {
+	p.listed = available
+
+	var (
+		sources     []MergeSource
+		unavailable []string
+	)
+
+	for i, s := range p.services {
+		if available[i] {
+			sources = append(sources, MergeSource{Provider: s.provider(), PathPrefix: s.PathPrefix, Namespace: s.Namespace})
+		} else {
+			unavailable = append(unavailable, s.Name)
+		}
+	}
+
+	if p.merge {
+		if len(sources) == 0 {
+			p.merged = DocProviderFunc(func(context.Context) ([]byte, string, error) {
+				return nil, "", errors.New("no service available")
+			})
+		} else {
+			p.merged = MergeProvider(sources...)
+		}
+	}
+
+	config := *p.base.config
+	config.unavailable = unavailable
+
+	h := *p.base
+	h.config = &config
+	p.handler = &h
+}
+
+// UnavailableServices returns the names of the services of a Portal which failed
+// their last refresh.
+func (d IndexData) UnavailableServices() []string {
+	return d.unavailable
+}
+
+// provider provides the last fetched document of the service.
+func (s *portalService) provider() DocProvider {
+	return DocProviderFunc(func(context.Context) ([]byte, string, error) {
+		s.remote.mu.Lock()
+		defer s.remote.mu.Unlock()
+
+		if s.remote.doc == nil {
+			return nil, "", fmt.Errorf("service %q unavailable", s.Name)
+		}
+
+		return s.remote.doc, s.remote.contentType, nil
+	})
+}
+
+// check fetches the document of the service and checks its health.
+func (s *portalService) check(ctx context.Context) {
+	err := s.remote.refresh(ctx, 0)
+	if err == nil && s.HealthURL != "" {
+		err = checkHealth(ctx, s.remote.config, s.HealthURL)
+	}
+
+	// the service is not to blame for the cancellation of the refresh
+	if ctx.Err() != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.status.Available = err == nil
+	s.status.Error = ""
+	s.status.CheckedAt = time.Now()
+
+	if err != nil {
+		s.status.Error = err.Error()
+	}
+}
+
+// checkHealth requests healthURL, expecting a 2xx status.
+func checkHealth(ctx context.Context, config RemoteConfig, healthURL string) error {
+	ctx, cancel := context.WithTimeout(ctx, config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthURL, nil)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range config.Header {
+		req.Header[k] = v
+	}
+
+	resp, err := config.Client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("health check %s: unexpected status %s", healthURL, resp.Status)
+	}
+
+	return nil
+}
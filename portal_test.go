@@ -0,0 +1,184 @@
+package httpSwagger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPortal(t *testing.T) {
+	pets := &upstream{doc: `{"swagger":"2.0","info":{"title":"Pets"},"paths":{"/pets":{}}}`}
+	orders := &upstream{doc: `{"swagger":"2.0","info":{"title":"Orders"},"paths":{"/orders":{}}}`}
+
+	healthy := true
+	services := http.NewServeMux()
+	services.Handle("/pets.json", pets)
+	services.Handle("/orders.json", orders)
+	services.HandleFunc("/orders/health", func(w http.ResponseWriter, r *http.Request) {
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+
+	server := httptest.NewServer(services)
+	defer server.Close()
+
+	config := PortalConfig{Services: []Service{
+		{Name: "Pets", URL: server.URL + "/pets.json"},
+		{Name: "Orders", URL: server.URL + "/orders.json", HealthURL: server.URL + "/orders/health"},
+	}}
+
+	portal, err := NewPortal(config, Title("API portal"))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	router := http.NewServeMux()
+	router.Handle("/portal/", portal)
+
+	// not fetched yet
+	assert.Equal(t, http.StatusInternalServerError, performRequest(http.MethodGet, "/portal/pets.json", router).Code)
+
+	portal.Refresh(context.Background())
+
+	assert.Equal(t, pets.doc, performRequest(http.MethodGet, "/portal/pets.json", router).Body.String())
+	assert.Equal(t, orders.doc, performRequest(http.MethodGet, "/portal/orders.json", router).Body.String())
+	assert.Contains(t, performRequest(http.MethodGet, "/portal/index.html", router).Body.String(), "<title>API portal</title>")
+	assert.Contains(t, performRequest(http.MethodGet, "/portal/swagger-config.json", router).Body.String(),
+		`"urls":[{"name":"Pets","url":"pets.json"},{"name":"Orders","url":"orders.json"}]`)
+
+	healthy = false
+	portal.Refresh(context.Background())
+
+	// the names are unchanged, so the unavailable services can still be selected
+	assert.Contains(t, performRequest(http.MethodGet, "/portal/swagger-config.json?urls.primaryName=Orders", router).Body.String(),
+		`"urls":[{"name":"Pets","url":"pets.json"},{"name":"Orders","url":"orders.json"}],"urls.primaryName":"Orders"`)
+	assert.Contains(t, performRequest(http.MethodGet, "/portal/index.html", router).Body.String(),
+		"<div class=\"availability-banner\" role=\"status\">\n  Unavailable services: Orders.\n</div>")
+	// only the index page configuration changes
+	assert.Same(t, portal.base.specs, portal.handler.specs)
+	// the last fetched document is still served
+	assert.Equal(t, orders.doc, performRequest(http.MethodGet, "/portal/orders.json", router).Body.String())
+
+	w := performRequest(http.MethodGet, "/portal/status.json", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var statuses []ServiceStatus
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &statuses))
+
+	if assert.Len(t, statuses, 2) {
+		assert.True(t, statuses[0].Available)
+		assert.False(t, statuses[1].Available)
+		assert.Equal(t, "health check "+server.URL+"/orders/health: unexpected status 503 Service Unavailable", statuses[1].Error)
+		assert.False(t, statuses[1].CheckedAt.IsZero())
+	}
+
+	for _, path := range []string{"/portal/status.json", "/portal/index.html", "/portal/pets.json", "/portal/swagger-ui.css"} {
+		w = performRequest(http.MethodHead, path, router)
+		assert.Equal(t, http.StatusOK, w.Code, path)
+		assert.NotEmpty(t, w.Header().Get("Content-Length"), path)
+		assert.Empty(t, w.Body.String(), path)
+	}
+
+	healthy = true
+	portal.Refresh(context.Background())
+
+	assert.NotContains(t, performRequest(http.MethodGet, "/portal/index.html", router).Body.String(), "availability-banner")
+}
+
+func TestPortalMerge(t *testing.T) {
+	pets := &upstream{doc: `{"swagger":"2.0","info":{"title":"Pets"},"paths":{"/pets":{}}}`}
+	orders := &upstream{doc: `{"swagger":"2.0","info":{"title":"Orders"},"paths":{"/":{}}}`}
+
+	services := http.NewServeMux()
+	services.Handle("/pets.json", pets)
+	services.Handle("/orders.json", orders)
+
+	server := httptest.NewServer(services)
+	defer server.Close()
+
+	portal, err := NewPortal(PortalConfig{
+		Services: []Service{
+			{Name: "Pets", URL: server.URL + "/pets.json"},
+			{Name: "Orders", URL: server.URL + "/orders.json", PathPrefix: "/orders"},
+			{Name: "Users", URL: server.URL + "/users.json"},
+		},
+		Merge: true,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// not merged yet
+	assert.Equal(t, http.StatusInternalServerError, performRequest(http.MethodGet, "/doc.json", portal).Code)
+
+	portal.Refresh(context.Background())
+
+	w := performRequest(http.MethodGet, "/doc.json", portal)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"swagger":"2.0","info":{"title":"Pets"},"paths":{"/pets":{},"/orders/":{}}}`, w.Body.String())
+	assert.Contains(t, performRequest(http.MethodGet, "/index.html", portal).Body.String(), "Unavailable services: Users.")
+}
+
+func TestPortalRun(t *testing.T) {
+	up := &upstream{doc: `{"swagger":"2.0"}`, served: make(chan struct{}, 1)}
+	server := httptest.NewServer(up)
+	defer server.Close()
+
+	portal, err := NewPortal(PortalConfig{
+		Services: []Service{{Name: "Pets", URL: server.URL}},
+		Interval: time.Millisecond,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() {
+		done <- portal.Run(ctx)
+	}()
+
+	// refreshed right away, then every interval
+	<-up.served
+	<-up.served
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestPortalConfig(t *testing.T) {
+	_, err := NewPortal(PortalConfig{})
+	assert.EqualError(t, err, "invalid configuration: no service")
+
+	_, err = NewPortal(PortalConfig{
+		Services: []Service{
+			{URL: "https://example.com/doc.json"},
+			{Name: "Status", URL: "/doc.json", HealthURL: "health"},
+			{Name: "Hot reload", URL: "https://example.com/reload.json"},
+			{Name: "Billing API", URL: "https://example.com/billing.json"},
+			{Name: "billing-api", URL: "https://example.com/billing/v2.json"},
+		},
+		Interval: -time.Second,
+	})
+	assert.EqualError(t, err, "invalid configuration: negative portal interval -1s; service 1 has no name; "+
+		`service "Status": name "status" is reserved; service "Status": invalid URL "/doc.json"; `+
+		`service "Status": invalid health URL "health"; service "Hot reload": name "hot-reload" is reserved; `+
+		`service "billing-api": document billing-api.json already served for service "Billing API"`)
+
+	_, err = NewPortal(PortalConfig{Services: []Service{{Name: "Pets", URL: "https://example.com/doc.json"}}}, DocExpansion("all"))
+	assert.EqualError(t, err, `invalid configuration: unsupported doc expansion "all"`)
+
+	// the documents are fetched after the handler is created
+	for _, merge := range []bool{false, true} {
+		_, err = NewPortal(PortalConfig{Services: []Service{{Name: "Pets", URL: "https://example.com/doc.json"}}, Merge: merge},
+			Validation(ReportValidation), HotReload(time.Second))
+		assert.EqualError(t, err, "invalid configuration: Validation is not supported by Portal; HotReload is not supported by Portal")
+	}
+}
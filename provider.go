@@ -0,0 +1,93 @@
+package httpSwagger
+
+import (
+	"context"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path/filepath"
+
+	"github.com/swaggo/swag"
+)
+
+// DocProvider provides the API definition served by the handler.
+type DocProvider interface {
+	// ReadDoc returns the API definition along with its content type.
+	// JSON and YAML documents are supported.
+	ReadDoc(ctx context.Context) ([]byte, string, error)
+}
+
+// DocProviderFunc is an adapter to allow the use of ordinary functions as DocProvider.
+type DocProviderFunc func(ctx context.Context) ([]byte, string, error)
+
+// ReadDoc calls f(ctx).
+func (f DocProviderFunc) ReadDoc(ctx context.Context) ([]byte, string, error) {
+	return f(ctx)
+}
+
+// SwagProvider provides the document registered in the swag registry under instanceName.
+func SwagProvider(instanceName string) DocProvider {
+	return DocProviderFunc(func(context.Context) ([]byte, string, error) {
+		doc, err := swag.ReadDoc(instanceName)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return []byte(doc), jsonContentType, nil
+	})
+}
+
+// FSProvider provides the document stored at name in fsys. Files with a `.yaml`
+// or `.yml` extension are read as YAML, any other file as JSON.
+func FSProvider(fsys fs.FS, name string) DocProvider {
+	contentType := jsonContentType
+	if ext := filepath.Ext(name); ext == ".yaml" || ext == ".yml" {
+		contentType = yamlContentType
+	}
+
+	return DocProviderFunc(func(context.Context) ([]byte, string, error) {
+		doc, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return doc, contentType, nil
+	})
+}
+
+// BytesProvider provides a static document with the given content type.
+func BytesProvider(doc []byte, contentType string) DocProvider {
+	return DocProviderFunc(func(context.Context) ([]byte, string, error) {
+		return doc, contentType, nil
+	})
+}
+
+// providerDoc reads a document from p, converting YAML documents to JSON.
+func providerDoc(p DocProvider) docReader {
+	return func(r *http.Request) ([]byte, error) {
+		doc, contentType, err := p.ReadDoc(r.Context())
+		if err != nil {
+			return nil, err
+		}
+
+		if isYAML(contentType) {
+			return yamlToJSON(doc)
+		}
+
+		return doc, nil
+	}
+}
+
+func isYAML(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	switch mediaType {
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,76 @@
+package httpSwagger
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggo/swag"
+)
+
+func TestSwagProvider(t *testing.T) {
+	swag.Register("provider", &mockedSwag{})
+
+	doc, contentType, err := SwagProvider("provider").ReadDoc(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "application/json; charset=utf-8", contentType)
+	assert.Equal(t, (&mockedSwag{}).ReadDoc(), string(doc))
+
+	_, _, err = SwagProvider("unregistered").ReadDoc(context.Background())
+	assert.Error(t, err)
+}
+
+func TestFSProvider(t *testing.T) {
+	fsys := fstest.MapFS{
+		"api/doc.json": {Data: []byte(`{"swagger":"2.0"}`)},
+		"api/doc.yml":  {Data: []byte("swagger: \"2.0\"\n")},
+	}
+
+	doc, contentType, err := FSProvider(fsys, "api/doc.json").ReadDoc(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "application/json; charset=utf-8", contentType)
+	assert.Equal(t, `{"swagger":"2.0"}`, string(doc))
+
+	doc, contentType, err = FSProvider(fsys, "api/doc.yml").ReadDoc(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "application/yaml; charset=utf-8", contentType)
+	assert.Equal(t, "swagger: \"2.0\"\n", string(doc))
+
+	_, _, err = FSProvider(fsys, "api/missing.json").ReadDoc(context.Background())
+	assert.Error(t, err)
+}
+
+func TestProvider(t *testing.T) {
+	fsys := fstest.MapFS{
+		"doc.yaml": {Data: []byte("swagger: \"2.0\"\ninfo:\n  title: From FS\n  version: 1.0.0\npaths: {}\n")},
+	}
+
+	router := http.NewServeMux()
+	router.Handle("/bytes/", Handler(Provider(BytesProvider([]byte(`{"swagger":"2.0","paths":{}}`), "application/json"))))
+	router.Handle("/fs/", Handler(Provider(FSProvider(fsys, "doc.yaml"))))
+	router.Handle("/func/", Handler(Provider(DocProviderFunc(func(ctx context.Context) ([]byte, string, error) {
+		return nil, "", errors.New("unavailable")
+	}))))
+	router.Handle("/urls/", Handler(URLs(SpecURL{Name: "FS", InstanceName: "fs", Provider: FSProvider(fsys, "doc.yaml")})))
+
+	w1 := performRequest(http.MethodGet, "/bytes/doc.json", router)
+	assert.Equal(t, http.StatusOK, w1.Code)
+	assert.Equal(t, `{"swagger":"2.0","paths":{}}`, w1.Body.String())
+
+	w2 := performRequest(http.MethodGet, "/fs/doc.json", router)
+	assert.Equal(t, http.StatusOK, w2.Code)
+	assert.Equal(t, `{"swagger":"2.0","info":{"title":"From FS","version":"1.0.0"},"paths":{}}`, w2.Body.String())
+
+	w3 := performRequest(http.MethodGet, "/fs/doc.yaml", router)
+	assert.Equal(t, http.StatusOK, w3.Code)
+	assert.Equal(t, "swagger: \"2.0\"\ninfo:\n  title: From FS\n  version: 1.0.0\npaths: {}\n", w3.Body.String())
+
+	assert.Equal(t, http.StatusInternalServerError, performRequest(http.MethodGet, "/func/doc.json", router).Code)
+
+	w4 := performRequest(http.MethodGet, "/urls/fs.json", router)
+	assert.Equal(t, http.StatusOK, w4.Code)
+	assert.Equal(t, w2.Body.String(), w4.Body.String())
+}
@@ -0,0 +1,154 @@
+package httpSwagger
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HotReload polls the documents every interval while index pages are open, and
+// makes them reload the documentation when a document changes. It is meant for
+// development, e.g. along with an FSProvider reading the output of `swag init`.
+func HotReload(interval time.Duration) func(*Config) {
+	return func(c *Config) {
+		if interval <= 0 {
+			c.errs = append(c.errs, fmt.Errorf("invalid hot reload interval %v", interval))
+
+			return
+		}
+
+		c.HotReload = interval
+	}
+}
+
+// reloader polls documents and notifies its subscribers of their changes.
+// It only polls while it has subscribers.
+type reloader struct {
+	names    []string
+	docs     map[string]docReader
+	interval time.Duration
+
+	mu      sync.Mutex
+	clients map[chan string]bool
+	stop    chan struct{}
+}
+
+func newReloader(names []string, docs map[string]docReader, interval time.Duration) *reloader {
+	return &reloader{
+		names:    names,
+		docs:     docs,
+		interval: interval,
+		clients:  make(map[chan string]bool),
+	}
+}
+
+// subscribe returns a channel receiving the names of the changed documents.
+func (rl *reloader) subscribe() chan string {
+	ch := make(chan string, 1)
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.clients[ch] = true
+	if len(rl.clients) == 1 {
+		rl.stop = make(chan struct{})
+		go rl.poll(rl.stop)
+	}
+
+	return ch
+}
+
+func (rl *reloader) unsubscribe(ch chan string) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	delete(rl.clients, ch)
+	if len(rl.clients) == 0 {
+		close(rl.stop)
+	}
+}
+
+// poll reads the documents every interval until stop is closed.
+func (rl *reloader) poll(stop chan struct{}) {
+	r, _ := http.NewRequest(http.MethodGet, "/", nil)
+
+	hashes := make(map[string][sha256.Size]byte, len(rl.names))
+
+	check := func(notify bool) {
+		for _, name := range rl.names {
+			doc, err := rl.docs[name](r)
+			if err != nil {
+				continue
+			}
+
+			sum := sha256.Sum256(doc)
+			if prev, ok := hashes[name]; ok && prev != sum && notify {
+				rl.broadcast(name + ".json")
+			}
+
+			hashes[name] = sum
+		}
+	}
+
+	check(false)
+
+	ticker := time.NewTicker(rl.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			check(true)
+		}
+	}
+}
+
+func (rl *reloader) broadcast(name string) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	for ch := range rl.clients {
+		// a pending notification already reloads the documentation
+		select {
+		case ch <- name:
+		default:
+		}
+	}
+}
+
+// serve streams the names of the changed documents as server-sent `reload` events.
+func (rl *reloader) serve(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+
+		return
+	}
+
+	ch := rl.subscribe()
+	defer rl.unsubscribe(ch)
+
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case name := <-ch:
+			fmt.Fprintf(w, "event: reload\ndata: %s\n\n", name)
+			flusher.Flush()
+		}
+	}
+}
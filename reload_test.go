@@ -0,0 +1,112 @@
+package httpSwagger
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHotReload(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		doc   = `{"swagger":"2.0","info":{"version":"1"}}`
+		reads = make(chan struct{}, 1)
+	)
+
+	setDoc := func(d string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		doc = d
+	}
+
+	router := http.NewServeMux()
+	router.Handle("/swagger/", Handler(
+		Provider(DocProviderFunc(func(ctx context.Context) ([]byte, string, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			select {
+			case reads <- struct{}{}:
+			default:
+			}
+
+			return []byte(doc), "application/json", nil
+		})),
+		HotReload(5*time.Millisecond),
+	))
+	router.Handle("/disabled/", Handler())
+
+	w := performRequest(http.MethodGet, "/swagger/index.html", router)
+	assert.Contains(t, w.Body.String(), "\n  <script src=\"./hot-reload.js\"> </script>\n</head>")
+
+	w = performRequest(http.MethodGet, "/swagger/hot-reload.js", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `new EventSource("./hot-reload")`)
+
+	assert.NotContains(t, performRequest(http.MethodGet, "/disabled/index.html", router).Body.String(), "hot-reload.js")
+	assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/disabled/hot-reload", router).Code)
+
+	// HEAD requests do not subscribe
+	w = performRequest(http.MethodHead, "/swagger/hot-reload", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/swagger/hot-reload")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// let the first poll record the current document
+	<-reads
+	setDoc(`{"swagger":"2.0","info":{"version":"2"}}`)
+
+	lines := bufio.NewScanner(resp.Body)
+	assert.True(t, lines.Scan())
+	assert.Equal(t, "event: reload", lines.Text())
+	assert.True(t, lines.Scan())
+	assert.Equal(t, "data: doc.json", lines.Text())
+
+	_, err = NewHandler(HotReload(0))
+	assert.EqualError(t, err, "invalid configuration: invalid hot reload interval 0s")
+}
+
+func TestReloaderSubscriptions(t *testing.T) {
+	polls := make(chan struct{}, 100)
+	rl := newReloader([]string{"doc"}, map[string]docReader{
+		"doc": func(r *http.Request) ([]byte, error) {
+			polls <- struct{}{}
+
+			return []byte("{}"), nil
+		},
+	}, time.Millisecond)
+
+	a, b := rl.subscribe(), rl.subscribe()
+	<-polls
+
+	rl.unsubscribe(a)
+	rl.unsubscribe(b)
+	assert.Empty(t, rl.clients)
+
+	// polling stops with the last subscriber
+	time.Sleep(10 * time.Millisecond)
+	for len(polls) > 0 {
+		<-polls
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	assert.Empty(t, polls)
+}
@@ -0,0 +1,255 @@
+package httpSwagger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RemoteConfig controls how remote documents are fetched and cached.
+type RemoteConfig struct {
+	// Client fetches the documents. Defaults to a client with Timeout.
+	Client *http.Client
+	// Timeout bounds each fetch. Defaults to 10 seconds.
+	Timeout time.Duration
+	// TTL is the duration a fetched document is served without being fetched again.
+	// Defaults to 1 minute.
+	TTL time.Duration
+	// StaleWhileRevalidate is the duration past TTL an outdated document is still
+	// served while it is fetched again in the background.
+	StaleWhileRevalidate time.Duration
+	// MaxSize is the maximum size of a document in bytes. Defaults to 10 MiB.
+	MaxSize int64
+	// Header holds additional request headers, e.g. credentials.
+	Header http.Header
+}
+
+const (
+	defaultRemoteTimeout = 10 * time.Second
+	defaultRemoteTTL     = time.Minute
+	defaultRemoteMaxSize = 10 << 20
+)
+
+func (c RemoteConfig) withDefaults() RemoteConfig {
+	if c.Timeout == 0 {
+		c.Timeout = defaultRemoteTimeout
+	}
+
+	if c.TTL == 0 {
+		c.TTL = defaultRemoteTTL
+	}
+
+	if c.MaxSize == 0 {
+		c.MaxSize = defaultRemoteMaxSize
+	}
+
+	if c.Client == nil {
+		c.Client = &http.Client{Timeout: c.Timeout}
+	}
+
+	return c
+}
+
+// validate returns the problems of the remote configuration.
+func (c RemoteConfig) validate() []error {
+	var errs []error
+
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"timeout", c.Timeout},
+		{"TTL", c.TTL},
+		{"stale-while-revalidate duration", c.StaleWhileRevalidate},
+	} {
+		if d.value < 0 {
+			errs = append(errs, fmt.Errorf("negative remote %s %v", d.name, d.value))
+		}
+	}
+
+	if c.MaxSize < 0 {
+		errs = append(errs, fmt.Errorf("negative remote max size %d", c.MaxSize))
+	}
+
+	return errs
+}
+
+// Proxy makes the handler fetch the remote documents set with URL or URLs itself and
+// serve them locally, which avoids CORS issues in the browser. URL is then served at
+// `doc.json`, and each definition of URLs at a name derived from its Name, e.g.
+// `billing-api.json` for "Billing API".
+func Proxy(config RemoteConfig) func(*Config) {
+	return func(c *Config) {
+		c.errs = append(c.errs, config.validate()...)
+		c.Proxy = &config
+	}
+}
+
+// RemoteProvider provides the document fetched from rawURL, cached according to config.
+func RemoteProvider(rawURL string, config RemoteConfig) DocProvider {
+	return &remoteProvider{url: rawURL, config: config.withDefaults()}
+}
+
+// remoteProvider fetches a remote document and caches it.
+type remoteProvider struct {
+	url    string
+	config RemoteConfig
+
+	mu          sync.Mutex
+	doc         []byte
+	contentType string
+	etag        string
+	fetched     time.Time
+	refreshing  bool
+
+	// fetchMu serializes the fetches
+	fetchMu sync.Mutex
+}
+
+// ReadDoc implements DocProvider.
+func (p *remoteProvider) ReadDoc(ctx context.Context) ([]byte, string, error) {
+	p.mu.Lock()
+	age := time.Since(p.fetched)
+	cached := p.doc != nil
+
+	switch {
+	case cached && age < p.config.TTL:
+		defer p.mu.Unlock()
+
+		return p.doc, p.contentType, nil
+	case cached && age < p.config.TTL+p.config.StaleWhileRevalidate:
+		defer p.mu.Unlock()
+
+		if !p.refreshing {
+			p.refreshing = true
+
+			go func() {
+				_ = p.refresh(context.Background(), age)
+
+				p.mu.Lock()
+				p.refreshing = false
+				p.mu.Unlock()
+			}()
+		}
+
+		return p.doc, p.contentType, nil
+	}
+
+	p.mu.Unlock()
+
+	if err := p.refresh(ctx, age); err != nil {
+		return nil, "", err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.doc, p.contentType, nil
+}
+
+// refresh fetches the document again unless it was fetched by another call
+// since it was found to be age old.
+func (p *remoteProvider) refresh(ctx context.Context, age time.Duration) error {
+	p.fetchMu.Lock()
+	defer p.fetchMu.Unlock()
+
+	p.mu.Lock()
+	etag := p.etag
+	fresh := p.doc != nil && time.Since(p.fetched) < age
+	p.mu.Unlock()
+
+	if fresh {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range p.config.Header {
+		req.Header[k] = v
+	}
+
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := p.config.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && etag != "" {
+		p.mu.Lock()
+		p.fetched = time.Now()
+		p.mu.Unlock()
+
+		return nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching %s: unexpected status %s", p.url, resp.Status)
+	}
+
+	doc, err := io.ReadAll(io.LimitReader(resp.Body, p.config.MaxSize+1))
+	if err != nil {
+		return fmt.Errorf("fetching %s: %w", p.url, err)
+	}
+
+	if int64(len(doc)) > p.config.MaxSize {
+		return fmt.Errorf("fetching %s: document exceeds %d bytes", p.url, p.config.MaxSize)
+	}
+
+	p.mu.Lock()
+	p.doc = doc
+	p.contentType = resp.Header.Get("Content-Type")
+	p.etag = resp.Header.Get("ETag")
+	p.fetched = time.Now()
+	p.mu.Unlock()
+
+	return nil
+}
+
+// isRemoteURL reports whether s is an absolute HTTP URL.
+func isRemoteURL(s string) bool {
+	u, err := url.Parse(s)
+
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// proxyName derives the local name of the i-th proxied definition from its display name.
+func proxyName(name string, i int) string {
+	var b strings.Builder
+
+	dash := false
+
+	for _, r := range strings.ToLower(name) {
+		if 'a' <= r && r <= 'z' || '0' <= r && r <= '9' {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+
+			b.WriteRune(r)
+
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+
+	if b.Len() == 0 {
+		return fmt.Sprintf("remote-%d", i+1)
+	}
+
+	return b.String()
+}
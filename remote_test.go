@@ -0,0 +1,233 @@
+package httpSwagger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// upstream serves doc, counting the requests and honoring If-None-Match.
+type upstream struct {
+	mu       sync.Mutex
+	doc      string
+	etag     string
+	requests int
+	header   http.Header
+	served   chan struct{}
+}
+
+func (u *upstream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.requests++
+	u.header = r.Header.Clone()
+
+	defer func() {
+		select {
+		case u.served <- struct{}{}:
+		default:
+		}
+	}()
+
+	if u.etag != "" {
+		w.Header().Set("ETag", u.etag)
+
+		if r.Header.Get("If-None-Match") == u.etag {
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(u.doc))
+}
+
+func (u *upstream) set(doc, etag string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.doc, u.etag = doc, etag
+}
+
+func (u *upstream) count() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return u.requests
+}
+
+func TestProxy(t *testing.T) {
+	up := &upstream{doc: `{"swagger":"2.0","info":{"title":"Pets"}}`}
+	server := httptest.NewServer(up)
+	defer server.Close()
+
+	router := http.NewServeMux()
+	router.Handle("/single/", Handler(
+		URL(server.URL+"/pets.json"),
+		Proxy(RemoteConfig{Header: http.Header{"Authorization": {"Bearer token"}}}),
+	))
+	router.Handle("/multi/", Handler(
+		URLs(
+			SpecURL{Name: "Pets API", URL: server.URL + "/pets.json"},
+			SpecURL{Name: "Local", URL: "/local.json"},
+		),
+		Proxy(RemoteConfig{}),
+	))
+	router.Handle("/direct/", Handler(URL(server.URL+"/pets.json")))
+
+	w := performRequest(http.MethodGet, "/single/doc.json", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, up.doc, w.Body.String())
+	assert.Equal(t, "Bearer token", up.header.Get("Authorization"))
+	assert.Contains(t, performRequest(http.MethodGet, "/single/swagger-config.json", router).Body.String(), `"url":"doc.json"`)
+
+	w = performRequest(http.MethodGet, "/multi/pets-api.yaml", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "swagger: \"2.0\"\ninfo:\n  title: Pets\n", w.Body.String())
+
+	w = performRequest(http.MethodGet, "/multi/swagger-config.json", router)
+	assert.Contains(t, w.Body.String(), `{"name":"Pets API","url":"pets-api.json"},{"name":"Local","url":"/local.json"}`)
+
+	// the requests above were served from the cache of each handler
+	assert.Equal(t, 2, up.count())
+
+	assert.Contains(t, performRequest(http.MethodGet, "/direct/swagger-config.json", router).Body.String(), server.URL+"/pets.json")
+}
+
+func TestRemoteProviderCache(t *testing.T) {
+	up := &upstream{doc: `{"v":1}`, served: make(chan struct{}, 1)}
+	server := httptest.NewServer(up)
+	defer server.Close()
+
+	p := RemoteProvider(server.URL, RemoteConfig{TTL: time.Hour, StaleWhileRevalidate: time.Hour}).(*remoteProvider)
+	ctx := context.Background()
+
+	read := func() string {
+		doc, contentType, err := p.ReadDoc(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "application/json", contentType)
+
+		return string(doc)
+	}
+
+	age := func(d time.Duration) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		p.fetched = time.Now().Add(-d)
+	}
+
+	assert.Equal(t, `{"v":1}`, read())
+	<-up.served
+
+	up.set(`{"v":2}`, "")
+
+	// fresh
+	assert.Equal(t, `{"v":1}`, read())
+	assert.Equal(t, 1, up.count())
+
+	// stale, refreshed in the background
+	age(90 * time.Minute)
+	assert.Equal(t, `{"v":1}`, read())
+	<-up.served
+
+	assert.Eventually(t, func() bool {
+		return read() == `{"v":2}`
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 2, up.count())
+
+	// expired, fetched before serving
+	up.set(`{"v":3}`, "")
+	age(3 * time.Hour)
+	assert.Equal(t, `{"v":3}`, read())
+	assert.Equal(t, 3, up.count())
+}
+
+func TestRemoteProviderRevalidate(t *testing.T) {
+	up := &upstream{doc: `{"v":1}`, etag: `"1"`}
+	server := httptest.NewServer(up)
+	defer server.Close()
+
+	p := RemoteProvider(server.URL, RemoteConfig{TTL: time.Hour}).(*remoteProvider)
+
+	doc, _, err := p.ReadDoc(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, `{"v":1}`, string(doc))
+
+	p.fetched = time.Now().Add(-2 * time.Hour)
+
+	doc, contentType, err := p.ReadDoc(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, `{"v":1}`, string(doc))
+	assert.Equal(t, "application/json", contentType)
+	assert.Equal(t, `"1"`, up.header.Get("If-None-Match"))
+	assert.WithinDuration(t, time.Now(), p.fetched, time.Minute)
+	assert.Equal(t, 2, up.count())
+}
+
+func TestRemoteProviderErrors(t *testing.T) {
+	router := http.NewServeMux()
+	router.HandleFunc("/missing", http.NotFound)
+	router.HandleFunc("/large", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat(" ", 11) + "{}"))
+	})
+	router.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	config := RemoteConfig{MaxSize: 12, Timeout: 10 * time.Millisecond}
+
+	_, _, err := RemoteProvider(server.URL+"/missing", config).ReadDoc(context.Background())
+	assert.EqualError(t, err, "fetching "+server.URL+"/missing: unexpected status 404 Not Found")
+
+	_, _, err = RemoteProvider(server.URL+"/large", config).ReadDoc(context.Background())
+	assert.EqualError(t, err, "fetching "+server.URL+"/large: document exceeds 12 bytes")
+
+	_, _, err = RemoteProvider(server.URL+"/slow", config).ReadDoc(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	h := http.NewServeMux()
+	h.Handle("/swagger/", Handler(URL(server.URL+"/missing"), Proxy(config)))
+	assert.Equal(t, http.StatusInternalServerError, performRequest(http.MethodGet, "/swagger/doc.json", h).Code)
+}
+
+func TestProxyValidation(t *testing.T) {
+	_, err := NewHandler(
+		URLs(
+			SpecURL{Name: "Pets", URL: "https://example.com/pets.json"},
+			SpecURL{Name: "pets!", URL: "https://example.com/other.json"},
+			SpecURL{Name: "Validation", URL: "https://example.com/validation.json"},
+			SpecURL{Name: "OpenAPI", URL: "https://example.com/openapi.json"},
+			SpecURL{Name: "Config", InstanceName: "swagger-config"},
+		),
+		Proxy(RemoteConfig{Timeout: -time.Second, TTL: -time.Second}),
+		OpenAPI3(OpenAPI30),
+	)
+	assert.EqualError(t, err, "invalid configuration: negative remote timeout -1s; negative remote TTL -1s; "+
+		`definition "pets!": document pets.json already served for definition "Pets"; `+
+		`definition "Validation": name "validation" is reserved; definition "OpenAPI": name "openapi" is reserved; `+
+		`definition "Config": name "swagger-config" is reserved`)
+
+	// openapi is only reserved when documents are converted
+	_, err = NewHandler(URLs(SpecURL{Name: "OpenAPI", URL: "https://example.com/openapi.json"}), Proxy(RemoteConfig{}))
+	assert.NoError(t, err)
+}
+
+func TestProxyName(t *testing.T) {
+	assert.Equal(t, "billing-api-v2", proxyName(" Billing API (v2)", 0))
+	assert.Equal(t, "remote-3", proxyName("☃", 2))
+}
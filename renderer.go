@@ -0,0 +1,328 @@
+package httpSwagger
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"strings"
+)
+
+// RendererType is the documentation renderer of the index page.
+type RendererType string
+
+const (
+	SwaggerUIRenderer RendererType = "swagger-ui"
+	ReDocRenderer     RendererType = "redoc"
+	RapiDocRenderer   RendererType = "rapidoc"
+	ElementsRenderer  RendererType = "elements"
+)
+
+func (r RendererType) valid() bool {
+	switch r {
+	case SwaggerUIRenderer, ReDocRenderer, RapiDocRenderer, ElementsRenderer:
+		return true
+	}
+
+	return false
+}
+
+//go:generate go run ./internal/fetchassets -dir static https://cdn.jsdelivr.net/npm/redoc@2.1.5/bundles/redoc.standalone.js
+//go:generate go run ./internal/fetchassets -dir static https://cdn.jsdelivr.net/npm/rapidoc@9.3.8/dist/rapidoc-min.js
+//go:generate go run ./internal/fetchassets -dir static https://cdn.jsdelivr.net/npm/@stoplight/elements@8.4.7/web-components.min.js https://cdn.jsdelivr.net/npm/@stoplight/elements@8.4.7/styles.min.css
+
+// rendererAssets lists the assets of the renderers other than Swagger UI, embedded
+// from the pinned versions fetched by go generate.
+var rendererAssets = map[RendererType][]string{
+	ReDocRenderer:    {"redoc.standalone.js"},
+	RapiDocRenderer:  {"rapidoc-min.js"},
+	ElementsRenderer: {"web-components.min.js", "styles.min.css"},
+}
+
+// ReDocConfig holds the ReDoc options, see https://redocly.com/docs/redoc/config.
+type ReDocConfig struct {
+	DisableSearch           bool   `json:"disableSearch,omitempty"`
+	ExpandResponses         string `json:"expandResponses,omitempty"`
+	HideDownloadButton      bool   `json:"hideDownloadButton,omitempty"`
+	HideHostname            bool   `json:"hideHostname,omitempty"`
+	NativeScrollbars        bool   `json:"nativeScrollbars,omitempty"`
+	PathInMiddlePanel       bool   `json:"pathInMiddlePanel,omitempty"`
+	RequiredPropsFirst      bool   `json:"requiredPropsFirst,omitempty"`
+	SortPropsAlphabetically bool   `json:"sortPropsAlphabetically,omitempty"`
+}
+
+// RapiDocConfig holds the RapiDoc options, see https://rapidocweb.com/api.html.
+type RapiDocConfig struct {
+	// Theme is either `light` or `dark`.
+	Theme string
+	// RenderStyle is one of `read`, `view` or `focus`.
+	RenderStyle string
+	// Layout is either `row` or `column`.
+	Layout       string
+	PrimaryColor string
+	HideTryIt    bool
+	HideHeader   bool
+}
+
+// ElementsConfig holds the Stoplight Elements options, see
+// https://docs.stoplight.io/docs/elements/b074dc47b2826-elements-configuration-options.
+type ElementsConfig struct {
+	// Layout is either `sidebar` or `stacked`.
+	Layout string
+	// Router is one of `hash`, `history` or `memory`. Defaults to `hash`, which
+	// works whatever the prefix the handler is mounted at.
+	Router      string
+	HideTryIt   bool
+	HideSchemas bool
+	HideExport  bool
+}
+
+// Renderer selects the documentation renderer of the index page. Defaults to SwaggerUIRenderer.
+func Renderer(renderer RendererType) func(*Config) {
+	return func(c *Config) {
+		if !renderer.valid() {
+			c.errs = append(c.errs, fmt.Errorf("unsupported renderer %q", renderer))
+
+			return
+		}
+
+		c.Renderer = renderer
+	}
+}
+
+// ReDoc renders the documentation with ReDoc, a read-only renderer.
+func ReDoc(config ReDocConfig) func(*Config) {
+	return func(c *Config) {
+		c.Renderer = ReDocRenderer
+		c.ReDoc = &config
+	}
+}
+
+// RapiDoc renders the documentation with RapiDoc.
+func RapiDoc(config RapiDocConfig) func(*Config) {
+	return func(c *Config) {
+		for _, opt := range []struct{ name, value, allowed string }{
+			{"theme", config.Theme, "light dark"},
+			{"render style", config.RenderStyle, "read view focus"},
+			{"layout", config.Layout, "row column"},
+		} {
+			if opt.value != "" && !containsWord(opt.allowed, opt.value) {
+				c.errs = append(c.errs, fmt.Errorf("unsupported RapiDoc %s %q", opt.name, opt.value))
+			}
+		}
+
+		c.Renderer = RapiDocRenderer
+		c.RapiDoc = &config
+	}
+}
+
+// Elements renders the documentation with Stoplight Elements.
+func Elements(config ElementsConfig) func(*Config) {
+	return func(c *Config) {
+		for _, opt := range []struct{ name, value, allowed string }{
+			{"layout", config.Layout, "sidebar stacked"},
+			{"router", config.Router, "hash history memory"},
+		} {
+			if opt.value != "" && !containsWord(opt.allowed, opt.value) {
+				c.errs = append(c.errs, fmt.Errorf("unsupported Elements %s %q", opt.name, opt.value))
+			}
+		}
+
+		c.Renderer = ElementsRenderer
+		c.Elements = &config
+	}
+}
+
+// RendererAssets sets the base URL the ReDoc, RapiDoc and Elements assets are loaded
+// from, e.g. `./` to serve them with Files, instead of the embedded ones.
+func RendererAssets(baseURL string) func(*Config) {
+	return func(c *Config) {
+		c.RendererAssets = baseURL
+	}
+}
+
+// RendererIntegrity sets the Subresource Integrity hashes the renderer assets are
+// checked against by the browsers, by asset name, e.g. `redoc.standalone.js` to
+// `sha384-...`. The hashes of the embedded assets are set by default. The assets
+// are loaded with CORS so that they can be checked.
+func RendererIntegrity(hashes map[string]string) func(*Config) {
+	return func(c *Config) {
+		c.RendererIntegrity = hashes
+	}
+}
+
+func containsWord(words, word string) bool {
+	for _, w := range strings.Fields(words) {
+		if w == word {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AssetURL returns the URL of the renderer asset called name.
+func (d IndexData) AssetURL(name string) string {
+	base := d.RendererAssets
+	if base == "" {
+		base = "."
+	}
+
+	return strings.TrimSuffix(base, "/") + "/" + name
+}
+
+// AssetIntegrity returns the Subresource Integrity hash of the renderer asset called
+// name, empty if unknown.
+func (d IndexData) AssetIntegrity(name string) string {
+	if integrity, ok := d.RendererIntegrity[name]; ok {
+		return integrity
+	}
+
+	return d.assetIntegrity[name]
+}
+
+// embeddedIntegrity returns the Subresource Integrity hashes of the embedded assets
+// of the renderer of c by name, nil when they are loaded from RendererAssets.
+func embeddedIntegrity(c *Config) (map[string]string, error) {
+	names := rendererAssets[c.Renderer]
+	if len(names) == 0 || c.RendererAssets != "" {
+		return nil, nil
+	}
+
+	hashes := make(map[string]string, len(names))
+
+	for _, name := range names {
+		content, err := fs.ReadFile(staticFiles, name)
+		if err != nil {
+			return nil, fmt.Errorf("%s assets are not embedded, see RendererAssets: %w", c.Renderer, err)
+		}
+
+		sum := sha512.Sum384(content)
+		hashes[name] = "sha384-" + base64.StdEncoding.EncodeToString(sum[:])
+	}
+
+	return hashes, nil
+}
+
+// SpecURL returns the URL of the definition rendered by single-definition renderers:
+// the one selected by PrimaryName, or URL.
+func (d IndexData) SpecURL() string {
+	for _, u := range d.URLs {
+		if u.Name == d.PrimaryName {
+			return u.URL
+		}
+	}
+
+	return d.URL
+}
+
+// ReDocOptions returns the JSON encoding of the ReDoc options.
+func (d IndexData) ReDocOptions() (template.JS, error) {
+	config := d.ReDoc
+	if config == nil {
+		config = &ReDocConfig{}
+	}
+
+	b, err := json.Marshal(config)
+
+	return template.JS(b), err
+}
+
+// rendererTemplate returns the index template of the renderer.
+func rendererTemplate(renderer RendererType) string {
+	switch renderer {
+	case ReDocRenderer:
+		return redocTempl
+	case RapiDocRenderer:
+		return rapidocTempl
+	case ElementsRenderer:
+		return elementsTempl
+	}
+
+	return indexTempl
+}
+
+// rendererHead is the head shared by the ReDoc, RapiDoc and Elements index templates.
+const rendererHead = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="UTF-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>{{with .Title}}{{.}}{{else}}API Reference{{end}}</title>
+  {{- if .Favicon32}}
+  <link rel="icon" type="image/png" href="{{.Favicon32}}" sizes="32x32" />
+  {{- end}}
+  {{- if .Favicon16}}
+  <link rel="icon" type="image/png" href="{{.Favicon16}}" sizes="16x16" />
+  {{- end}}
+  {{- block "renderer-stylesheets" .}}{{end}}
+  {{- range .Stylesheets}}
+  <link rel="stylesheet" type="text/css" href="{{.}}"{{if $.Nonce}} nonce="{{$.Nonce}}"{{end}} >
+  {{- end}}
+  <style{{if .Nonce}} nonce="{{.Nonce}}"{{end}}>
+    body {
+      margin: 0;
+      padding: 0;
+    }
+    {{- with .CustomCSS}}
+    {{.}}
+    {{- end}}
+  </style>
+  {{- if .HotReload}}
+  <script src="./hot-reload.js"{{if .Nonce}} nonce="{{.Nonce}}"{{end}}> </script>
+  {{- end}}
+  {{- with .Head}}
+  {{.}}
+  {{- end}}
+</head>
+`
+
+const redocTempl = rendererHead + `
+<body>
+<div id="redoc-container"></div>
+<script src="{{.AssetURL "redoc.standalone.js"}}"{{with .AssetIntegrity "redoc.standalone.js"}} integrity="{{.}}"{{end}} crossorigin="anonymous"{{if .Nonce}} nonce="{{.Nonce}}"{{end}}> </script>
+<script{{if .Nonce}} nonce="{{.Nonce}}"{{end}}>
+  Redoc.init({{with .Spec}}{{.}}{{else}}{{.SpecURL}}{{end}}, {{.ReDocOptions}}, document.getElementById("redoc-container"))
+</script>
+</body>
+</html>
+`
+
+const rapidocTempl = rendererHead + `
+<body>
+<script type="module" src="{{.AssetURL "rapidoc-min.js"}}"{{with .AssetIntegrity "rapidoc-min.js"}} integrity="{{.}}"{{end}} crossorigin="anonymous"{{if .Nonce}} nonce="{{.Nonce}}"{{end}}> </script>
+<rapi-doc{{if not .Spec}} spec-url="{{.SpecURL}}"{{end}}
+  {{- with .RapiDoc}}
+  {{- with .Theme}} theme="{{.}}"{{end}}
+  {{- with .RenderStyle}} render-style="{{.}}"{{end}}
+  {{- with .Layout}} layout="{{.}}"{{end}}
+  {{- with .PrimaryColor}} primary-color="{{.}}"{{end}}
+  {{- if .HideTryIt}} allow-try="false"{{end}}
+  {{- if .HideHeader}} show-header="false"{{end}}
+  {{- end}}> </rapi-doc>
+{{- with .Spec}}
+<script{{if $.Nonce}} nonce="{{$.Nonce}}"{{end}}>
+  window.addEventListener("DOMContentLoaded", () => document.querySelector("rapi-doc").loadSpec({{.}}))
+</script>
+{{- end}}
+</body>
+</html>
+`
+
+const elementsTempl = `{{define "renderer-stylesheets"}}
+  <link rel="stylesheet" type="text/css" href="{{.AssetURL "styles.min.css"}}"{{with .AssetIntegrity "styles.min.css"}} integrity="{{.}}"{{end}} crossorigin="anonymous"{{if .Nonce}} nonce="{{.Nonce}}"{{end}} >
+{{- end}}` + rendererHead + `
+<body>
+<script src="{{.AssetURL "web-components.min.js"}}"{{with .AssetIntegrity "web-components.min.js"}} integrity="{{.}}"{{end}} crossorigin="anonymous"{{if .Nonce}} nonce="{{.Nonce}}"{{end}}> </script>
+<elements-api {{with .Spec}}apiDescriptionDocument="{{.}}"{{else}}apiDescriptionUrl="{{.SpecURL}}"{{end}}
+  {{- with .Elements}}
+  {{- with .Layout}} layout="{{.}}"{{end}}
+  {{- if .HideTryIt}} hideTryIt="true"{{end}}
+  {{- if .HideSchemas}} hideSchemas="true"{{end}}
+  {{- if .HideExport}} hideExport="true"{{end}}
+  {{- end}} router="{{with .Elements}}{{with .Router}}{{.}}{{else}}hash{{end}}{{else}}hash{{end}}"> </elements-api>
+</body>
+</html>
+`
@@ -0,0 +1,124 @@
+package httpSwagger
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// embedRendererAssets replaces the embedded assets with fake renderer assets until
+// the end of the test.
+func embedRendererAssets(t *testing.T) {
+	files := fstest.MapFS{}
+
+	for _, names := range rendererAssets {
+		for _, name := range names {
+			files[name] = &fstest.MapFile{Data: []byte("/* " + name + " */")}
+		}
+	}
+
+	static := staticFiles
+	staticFiles = files
+
+	t.Cleanup(func() { staticFiles = static })
+}
+
+func TestRenderers(t *testing.T) {
+	embedRendererAssets(t)
+
+	router := http.NewServeMux()
+	router.Handle("/redoc/", Handler(ReDoc(ReDocConfig{HideDownloadButton: true, ExpandResponses: "200"})))
+	router.Handle("/rapidoc/", Handler(
+		RapiDoc(RapiDocConfig{Theme: "dark", RenderStyle: "read", HideTryIt: true}),
+		URLs(SpecURL{Name: "Users", URL: "users.json"}, SpecURL{Name: "Pets", URL: "pets.json"}),
+		PrimaryName("Pets"),
+		RendererAssets("https://cdn.jsdelivr.net/npm/rapidoc@9.3.8/dist/"),
+		RendererIntegrity(map[string]string{"rapidoc-min.js": "sha384-abc/="}),
+	))
+	router.Handle("/elements/", Handler(
+		Elements(ElementsConfig{Layout: "stacked", HideSchemas: true}),
+		StrictCSP(true),
+		Stylesheets("./company.css"),
+	))
+	router.Handle("/swagger-ui/", Handler(Renderer(SwaggerUIRenderer)))
+
+	sum := sha512.Sum384([]byte("/* redoc.standalone.js */"))
+	integrity := "sha384-" + base64.StdEncoding.EncodeToString(sum[:])
+
+	w := performRequest(http.MethodGet, "/redoc/index.html", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "<title>API Reference</title>")
+	assert.Contains(t, w.Body.String(), `<script src="./redoc.standalone.js" integrity="`+strings.ReplaceAll(integrity, "+", "&#43;")+`" crossorigin="anonymous"> </script>`)
+	assert.Contains(t, w.Body.String(), `Redoc.init("doc.json", {"expandResponses":"200","hideDownloadButton":true}, document.getElementById("redoc-container"))`)
+
+	w = performRequest(http.MethodGet, "/redoc/redoc.standalone.js", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/javascript", w.Header().Get("Content-Type"))
+	assert.Equal(t, "/* redoc.standalone.js */", w.Body.String())
+
+	w = performRequest(http.MethodGet, "/rapidoc/index.html?urls.primaryName=Users", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `<script type="module" src="https://cdn.jsdelivr.net/npm/rapidoc@9.3.8/dist/rapidoc-min.js" integrity="sha384-abc/=" crossorigin="anonymous"> </script>`)
+	assert.Contains(t, w.Body.String(), `<rapi-doc spec-url="users.json" theme="dark" render-style="read" allow-try="false"> </rapi-doc>`)
+
+	w = performRequest(http.MethodGet, "/elements/index.html", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	nonce := w.Header().Get("Content-Security-Policy")[len("script-src 'self' 'nonce-"):][:22]
+	assert.Regexp(t, `<link rel="stylesheet" type="text/css" href="./styles.min.css" integrity="sha384-[^"]+" crossorigin="anonymous" nonce="`+nonce+`" >`, w.Body.String())
+	assert.Contains(t, w.Body.String(), `<link rel="stylesheet" type="text/css" href="./company.css" nonce="`+nonce+`" >`)
+	assert.Regexp(t, `<script src="./web-components.min.js" integrity="sha384-[^"]+" crossorigin="anonymous" nonce="`+nonce+`"> </script>`, w.Body.String())
+	assert.Contains(t, w.Body.String(), `<elements-api apiDescriptionUrl="doc.json" layout="stacked" hideSchemas="true" router="hash"> </elements-api>`)
+
+	w = performRequest(http.MethodGet, "/swagger-ui/index.html", router)
+	assert.Contains(t, w.Body.String(), "SwaggerUIBundle(")
+}
+
+func TestRendererErrors(t *testing.T) {
+	_, err := NewHandler(
+		Renderer("scalar"),
+		RapiDoc(RapiDocConfig{Theme: "blue", Layout: "grid"}),
+		Elements(ElementsConfig{Router: "path"}),
+		RendererAssets("%zz"),
+		RendererIntegrity(map[string]string{"web-components.min.js": "md5-abc"}),
+	)
+	assert.EqualError(t, err, `invalid configuration: unsupported renderer "scalar"; `+
+		`unsupported RapiDoc theme "blue"; unsupported RapiDoc layout "grid"; unsupported Elements router "path"; `+
+		`renderer assets: parse "%zz": invalid URL escape "%zz"; `+
+		`renderer asset web-components.min.js: invalid integrity "md5-abc"`)
+
+	// the Swagger UI settings are ignored by the other renderers
+	_, err = NewHandler(
+		ReDoc(ReDocConfig{}),
+		RendererAssets("./"),
+		StrictCSP(true),
+		Theme(ThemeDark),
+		ThemeSwitcher(true),
+		OAuth(OAuthConfig{ClientID: "id"}),
+		ExternalInitializer(true),
+		AfterScript(`console.log("loaded")`),
+		PluginNames("SomePlugin"),
+		UIConfigValues(map[string]UIValue{"filter": StringValue("abc")}),
+	)
+	assert.EqualError(t, err, `invalid configuration: StrictCSP is not supported by the redoc renderer; `+
+		`Theme is not supported by the redoc renderer; ThemeSwitcher is not supported by the redoc renderer; `+
+		`OAuth is not supported by the redoc renderer; ExternalInitializer is not supported by the redoc renderer; `+
+		`AfterScript is not supported by the redoc renderer; Plugins is not supported by the redoc renderer; `+
+		`UIConfig is not supported by the redoc renderer`)
+
+	// the assets are either embedded or loaded from RendererAssets
+	static := staticFiles
+	staticFiles = fstest.MapFS{}
+
+	defer func() { staticFiles = static }()
+
+	_, err = NewHandler(Elements(ElementsConfig{}))
+	assert.EqualError(t, err, `invalid configuration: elements assets are not embedded, see RendererAssets: open web-components.min.js: file does not exist`)
+
+	_, err = NewHandler(Elements(ElementsConfig{}), RendererAssets("https://cdn.example.com/elements/"))
+	assert.NoError(t, err)
+}
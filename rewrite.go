@@ -0,0 +1,228 @@
+package httpSwagger
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RewriteServer rewrites the host, schemes and basePath of the served documents to match
+// each request, so "Try it out" targets the server the documentation was loaded from.
+// The X-Forwarded-Host, X-Forwarded-Proto, X-Forwarded-Prefix and Forwarded (RFC 7239)
+// headers are honored for requests coming from trustedProxies, given as IP addresses
+// or CIDR ranges.
+func RewriteServer(trustedProxies ...string) func(*Config) {
+	return func(c *Config) {
+		c.RewriteServer = true
+		c.TrustedProxies = trustedProxies
+	}
+}
+
+// serverInfo describes the server a request was addressed to.
+type serverInfo struct {
+	scheme string
+	host   string
+	// prefix is the path prefix stripped by a reverse proxy.
+	prefix string
+}
+
+// parseTrustedProxies parses IP addresses and CIDR ranges.
+func parseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(proxies))
+
+	for _, p := range proxies {
+		if !strings.Contains(p, "/") {
+			ip := net.ParseIP(p)
+			if ip == nil {
+				return nil, &net.ParseError{Type: "IP address", Text: p}
+			}
+
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+
+			continue
+		}
+
+		_, n, err := net.ParseCIDR(p)
+		if err != nil {
+			return nil, err
+		}
+
+		nets = append(nets, n)
+	}
+
+	return nets, nil
+}
+
+func isTrustedProxy(remoteAddr string, trusted []*net.IPNet) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, n := range trusted {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// requestServer returns the server r was addressed to, as seen by the client.
+func requestServer(r *http.Request, trusted []*net.IPNet) serverInfo {
+	s := serverInfo{scheme: "http", host: r.Host}
+	if r.TLS != nil {
+		s.scheme = "https"
+	}
+
+	if !isTrustedProxy(r.RemoteAddr, trusted) {
+		return s
+	}
+
+	if fwd := r.Header.Get("Forwarded"); fwd != "" {
+		host, proto := parseForwarded(fwd)
+		if host != "" {
+			s.host = host
+		}
+
+		if proto != "" {
+			s.scheme = proto
+		}
+	} else {
+		if host := firstValue(r.Header.Get("X-Forwarded-Host")); host != "" {
+			s.host = host
+		}
+
+		if proto := firstValue(r.Header.Get("X-Forwarded-Proto")); proto != "" {
+			s.scheme = strings.ToLower(proto)
+		}
+	}
+
+	if prefix := firstValue(r.Header.Get("X-Forwarded-Prefix")); prefix != "" {
+		s.prefix = "/" + strings.Trim(prefix, "/")
+	}
+
+	return s
+}
+
+// parseForwarded returns the host and proto parameters of the first element of a
+// Forwarded header, which describes the request as received by the outermost proxy.
+func parseForwarded(header string) (host, proto string) {
+	first := header
+	if i := strings.IndexByte(header, ','); i >= 0 {
+		first = header[:i]
+	}
+
+	for _, pair := range strings.Split(first, ";") {
+		kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		value := strings.Trim(kv[1], `"`)
+
+		switch strings.ToLower(kv[0]) {
+		case "host":
+			host = value
+		case "proto":
+			proto = strings.ToLower(value)
+		}
+	}
+
+	return host, proto
+}
+
+func firstValue(header string) string {
+	if i := strings.IndexByte(header, ','); i >= 0 {
+		header = header[:i]
+	}
+
+	return strings.TrimSpace(header)
+}
+
+// rewriteServerDoc rewrites the server of the documents read by read to match each request.
+func rewriteServerDoc(read docReader, trusted []*net.IPNet) docReader {
+	return func(r *http.Request) ([]byte, error) {
+		doc, err := read(r)
+		if err != nil {
+			return nil, err
+		}
+
+		return rewriteServer(doc, requestServer(r, trusted))
+	}
+}
+
+// rewriteServer points a Swagger 2.0 or OpenAPI 3 document to s.
+func rewriteServer(doc []byte, s serverInfo) ([]byte, error) {
+	o, err := parseJSONObject(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := o.get("openapi"); ok {
+		if err := rewriteServers(&o, s); err != nil {
+			return nil, err
+		}
+
+		return o.MarshalJSON()
+	}
+
+	var basePath string
+	if _, err := o.decode("basePath", &basePath); err != nil {
+		return nil, err
+	}
+
+	if err := o.set("host", s.host); err != nil {
+		return nil, err
+	}
+
+	if err := o.set("schemes", []string{s.scheme}); err != nil {
+		return nil, err
+	}
+
+	if s.prefix != "" {
+		if err := o.set("basePath", s.prefix+"/"+strings.TrimPrefix(basePath, "/")); err != nil {
+			return nil, err
+		}
+	}
+
+	return o.MarshalJSON()
+}
+
+// rewriteServers points the servers of an OpenAPI 3 document to s, keeping their paths.
+func rewriteServers(o *jsonObject, s serverInfo) error {
+	var servers []map[string]interface{}
+	if _, err := o.decode("servers", &servers); err != nil {
+		return err
+	}
+
+	if len(servers) == 0 {
+		servers = []map[string]interface{}{{"url": "/"}}
+	}
+
+	for _, server := range servers {
+		raw, _ := server["url"].(string)
+
+		u, err := url.Parse(raw)
+		if err != nil {
+			return err
+		}
+
+		u.Scheme, u.Host = s.scheme, s.host
+		u.Path = strings.TrimSuffix(s.prefix+"/"+strings.TrimPrefix(u.Path, "/"), "/")
+		server["url"] = u.String()
+	}
+
+	return o.set("servers", servers)
+}
@@ -0,0 +1,159 @@
+package httpSwagger
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggo/swag"
+)
+
+func TestParseTrustedProxies(t *testing.T) {
+	nets, err := parseTrustedProxies([]string{"10.0.0.0/8", "192.0.2.1", "::1"})
+	assert.NoError(t, err)
+	assert.Len(t, nets, 3)
+
+	assert.True(t, isTrustedProxy("10.1.2.3:4567", nets))
+	assert.True(t, isTrustedProxy("192.0.2.1:80", nets))
+	assert.True(t, isTrustedProxy("[::1]:80", nets))
+	assert.False(t, isTrustedProxy("192.0.2.2:80", nets))
+	assert.False(t, isTrustedProxy("invalid", nets))
+
+	_, err = parseTrustedProxies([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+
+	_, err = parseTrustedProxies([]string{"proxy.local"})
+	assert.Error(t, err)
+}
+
+func TestRequestServer(t *testing.T) {
+	trusted, _ := parseTrustedProxies([]string{"192.0.2.0/24"})
+
+	tests := []struct {
+		desc       string
+		remoteAddr string
+		tls        bool
+		headers    map[string]string
+		exp        serverInfo
+	}{
+		{
+			desc:       "direct request",
+			remoteAddr: "198.51.100.1:1234",
+			exp:        serverInfo{scheme: "http", host: "api.local"},
+		},
+		{
+			desc:       "direct TLS request",
+			remoteAddr: "198.51.100.1:1234",
+			tls:        true,
+			exp:        serverInfo{scheme: "https", host: "api.local"},
+		},
+		{
+			desc:       "untrusted proxy",
+			remoteAddr: "198.51.100.1:1234",
+			headers: map[string]string{
+				"X-Forwarded-Host":   "evil.example.org",
+				"X-Forwarded-Proto":  "https",
+				"X-Forwarded-Prefix": "/evil",
+			},
+			exp: serverInfo{scheme: "http", host: "api.local"},
+		},
+		{
+			desc:       "X-Forwarded headers",
+			remoteAddr: "192.0.2.10:1234",
+			headers: map[string]string{
+				"X-Forwarded-Host":   "api.example.org, proxy.local",
+				"X-Forwarded-Proto":  "HTTPS",
+				"X-Forwarded-Prefix": "/public/",
+			},
+			exp: serverInfo{scheme: "https", host: "api.example.org", prefix: "/public"},
+		},
+		{
+			desc:       "Forwarded header",
+			remoteAddr: "192.0.2.10:1234",
+			headers: map[string]string{
+				"Forwarded":         `for=198.51.100.1;host="api.example.org:8443";proto=https, for=192.0.2.20;host=proxy.local;proto=http`,
+				"X-Forwarded-Host":  "ignored.example.org",
+				"X-Forwarded-Proto": "http",
+			},
+			exp: serverInfo{scheme: "https", host: "api.example.org:8443"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "http://api.local/swagger/doc.json", nil)
+			r.RemoteAddr = test.remoteAddr
+			if test.tls {
+				r.TLS = &tls.ConnectionState{}
+			}
+			for k, v := range test.headers {
+				r.Header.Set(k, v)
+			}
+
+			assert.Equal(t, test.exp, requestServer(r, trusted))
+		})
+	}
+}
+
+func TestRewriteServer(t *testing.T) {
+	s := serverInfo{scheme: "https", host: "api.example.org", prefix: "/public"}
+
+	out, err := rewriteServer([]byte(`{"swagger":"2.0","host":"localhost:8080","basePath":"/v2","schemes":["http"],"paths":{}}`), s)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"swagger":"2.0","host":"api.example.org","basePath":"/public/v2","schemes":["https"],"paths":{}}`, string(out))
+
+	out, err = rewriteServer([]byte(`{"swagger":"2.0","paths":{}}`), serverInfo{scheme: "http", host: "localhost"})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"swagger":"2.0","paths":{},"host":"localhost","schemes":["http"]}`, string(out))
+
+	out, err = rewriteServer([]byte(`{"openapi":"3.0.3","servers":[{"url":"http://localhost:8080/v2"},{"url":"/v3","description":"relative"}],"paths":{}}`), s)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"openapi":"3.0.3","servers":[{"url":"https://api.example.org/public/v2"},{"description":"relative","url":"https://api.example.org/public/v3"}],"paths":{}}`, string(out))
+
+	out, err = rewriteServer([]byte(`{"openapi":"3.1.0","paths":{}}`), serverInfo{scheme: "http", host: "localhost"})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"openapi":"3.1.0","paths":{},"servers":[{"url":"http://localhost"}]}`, string(out))
+
+	_, err = rewriteServer([]byte(`{"swagger":"2.0","basePath":1}`), s)
+	assert.Error(t, err)
+}
+
+func TestRewriteServerHandler(t *testing.T) {
+	swag.Register("rewrite", &mockedSwag{})
+
+	router := http.NewServeMux()
+	router.Handle("/swagger/", Handler(InstanceName("rewrite"), RewriteServer("192.0.2.0/24"), OpenAPI3(OpenAPI30)))
+
+	r := httptest.NewRequest(http.MethodGet, "http://localhost:8080/swagger/doc.json", nil)
+	r.Header.Set("X-Forwarded-Host", "api.example.org")
+	r.Header.Set("X-Forwarded-Proto", "https")
+	r.Header.Set("X-Forwarded-Prefix", "/public")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"host":"api.example.org"`)
+	assert.Contains(t, w.Body.String(), `"basePath":"/public/v2"`)
+	assert.Contains(t, w.Body.String(), `"schemes":["https"]`)
+
+	r = httptest.NewRequest(http.MethodGet, "http://localhost:8080/swagger/openapi.json", nil)
+	r.Header.Set("X-Forwarded-Host", "api.example.org")
+	r.Header.Set("X-Forwarded-Proto", "https")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"servers":[{"url":"https://api.example.org/v2"}]`)
+
+	r = httptest.NewRequest(http.MethodGet, "http://localhost:8080/swagger/doc.json", nil)
+	r.RemoteAddr = "198.51.100.1:1234"
+	r.Header.Set("X-Forwarded-Host", "api.example.org")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"host":"localhost:8080"`)
+	assert.Contains(t, w.Body.String(), `"schemes":["http"]`)
+}
@@ -0,0 +1,52 @@
+package httpSwagger
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Prefix sets the path prefix the handler is mounted at, as found in the URL path of the
+// requests it receives, e.g. `/swagger/`. By default the prefix is detected from each
+// request as the URL path up to its last slash, which works with http.StripPrefix and
+// wildcard routes of any router.
+func Prefix(prefix string) func(*Config) {
+	return func(c *Config) {
+		c.Prefix = prefix
+	}
+}
+
+// resolvePath returns the resource requested by r relative to the mount prefix of
+// the handler. It reports false when the URL path is outside of the prefix.
+func (c *Config) resolvePath(r *http.Request) (string, bool) {
+	path := r.URL.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	if c.Prefix == "" {
+		return path[strings.LastIndexByte(path, '/')+1:], true
+	}
+
+	prefix := "/" + strings.Trim(c.Prefix, "/") + "/"
+	if prefix == "//" {
+		prefix = "/"
+	}
+
+	if !strings.HasPrefix(path, prefix) {
+		return "", false
+	}
+
+	return strings.TrimPrefix(path, prefix), true
+}
+
+// redirectToIndex redirects to the index page with a relative location, which
+// stays correct whatever prefix was stripped from the request by routers.
+func redirectToIndex(w http.ResponseWriter, r *http.Request) {
+	location := "index.html"
+	if r.URL.RawQuery != "" {
+		location += "?" + r.URL.RawQuery
+	}
+
+	w.Header().Set("Location", location)
+	w.WriteHeader(http.StatusMovedPermanently)
+}
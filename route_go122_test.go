@@ -0,0 +1,21 @@
+//go:build go1.22
+
+//go:debug httpmuxgo121=0
+
+package httpSwagger
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/swaggo/swag"
+)
+
+func TestServeMuxPatterns(t *testing.T) {
+	swag.Register("routers-patterns", &mockedSwag{})
+
+	m := http.NewServeMux()
+	m.Handle("GET /swagger/{path...}", Handler(InstanceName("routers-patterns")))
+
+	checkRoutes(t, "/swagger/", m)
+}
@@ -0,0 +1,147 @@
+package httpSwagger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggo/swag"
+)
+
+func TestResolvePath(t *testing.T) {
+	tests := []struct {
+		prefix string
+		path   string
+		exp    string
+		ok     bool
+	}{
+		{prefix: "", path: "/swagger/index.html", exp: "index.html", ok: true},
+		{prefix: "", path: "/swagger/", exp: "", ok: true},
+		{prefix: "", path: "index.html", exp: "index.html", ok: true},
+		{prefix: "", path: "/a/b/c/doc.json", exp: "doc.json", ok: true},
+		{prefix: "/swagger/", path: "/swagger/index.html", exp: "index.html", ok: true},
+		{prefix: "swagger", path: "/swagger/doc.json", exp: "doc.json", ok: true},
+		{prefix: "/swagger/", path: "/swagger/v1/doc.json", exp: "v1/doc.json", ok: true},
+		{prefix: "/swagger/", path: "/other/index.html", exp: "", ok: false},
+		{prefix: "/", path: "index.html", exp: "index.html", ok: true},
+	}
+
+	for _, test := range tests {
+		cfg := &Config{Prefix: test.prefix}
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.URL.Path = test.path
+
+		path, ok := cfg.resolvePath(r)
+		assert.Equal(t, test.ok, ok, test.prefix+" "+test.path)
+		assert.Equal(t, test.exp, path, test.prefix+" "+test.path)
+	}
+}
+
+func TestRouters(t *testing.T) {
+	swag.Register("routers", &mockedSwag{})
+
+	newHandler := func(configFns ...func(*Config)) http.Handler {
+		return Handler(append(configFns, InstanceName("routers"))...)
+	}
+
+	tests := []struct {
+		desc   string
+		root   string
+		router func() http.Handler
+	}{
+		{
+			desc: "ServeMux",
+			root: "/swagger/",
+			router: func() http.Handler {
+				m := http.NewServeMux()
+				m.Handle("/swagger/", newHandler())
+
+				return m
+			},
+		},
+		{
+			desc: "StripPrefix",
+			root: "/api/docs/",
+			router: func() http.Handler {
+				m := http.NewServeMux()
+				m.Handle("/api/docs/", http.StripPrefix("/api/docs/", newHandler()))
+
+				return m
+			},
+		},
+		{
+			desc: "StripPrefix with explicit prefix",
+			root: "/api/docs/",
+			router: func() http.Handler {
+				m := http.NewServeMux()
+				m.Handle("/api/", http.StripPrefix("/api", newHandler(Prefix("/docs/"))))
+
+				return m
+			},
+		},
+		{
+			desc: "explicit prefix",
+			root: "/swagger/",
+			router: func() http.Handler {
+				m := http.NewServeMux()
+				m.Handle("/swagger/", newHandler(Prefix("/swagger/")))
+
+				return m
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			checkRoutes(t, test.root, test.router())
+		})
+	}
+}
+
+// checkRoutes checks the responses of router, serving the document of mockedSwag
+// under root.
+func checkRoutes(t *testing.T, root string, router http.Handler) {
+	t.Helper()
+
+	w := performRequest(http.MethodGet, root+"index.html", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+
+	w = performRequest(http.MethodGet, root+"index.html?urls.primaryName=a.b", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = performRequest(http.MethodGet, root+"index%2Ehtml", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = performRequest(http.MethodGet, root+"doc.json", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, (&mockedSwag{}).ReadDoc(), w.Body.String())
+
+	w = performRequest(http.MethodGet, root+"swagger-ui.css", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = performRequest(http.MethodGet, root+"oauth2-redirect.html?state=0&code=1.2", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+
+	w = performRequest(http.MethodGet, root, router)
+	assert.Equal(t, http.StatusMovedPermanently, w.Code)
+	assert.Equal(t, "index.html", w.Header().Get("Location"))
+
+	w = performRequest(http.MethodGet, root+"?urls.primaryName=Users", router)
+	assert.Equal(t, http.StatusMovedPermanently, w.Code)
+	assert.Equal(t, "index.html?urls.primaryName=Users", w.Header().Get("Location"))
+
+	w = performRequest(http.MethodGet, root+"notfound", router)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPrefixMismatch(t *testing.T) {
+	router := http.NewServeMux()
+	router.Handle("/", Handler(Prefix("/swagger/")))
+
+	assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/other/index.html", router).Code)
+	assert.Equal(t, http.StatusOK, performRequest(http.MethodGet, "/swagger/index.html", router).Code)
+	assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/swagger/nested/index.html", router).Code)
+}
@@ -0,0 +1,580 @@
+package httpSwagger
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+//go:generate go run ./internal/fetchassets -dir schemas -name openapi-3.0.json https://spec.openapis.org/oas/3.0/schema/2021-09-28
+
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+// documentSchemas holds the JSON schemas of the document versions, loaded with the
+// JSON Schema meta-schema they reference.
+var documentSchemas = loadSchemas(map[string]string{
+	"2.0": "schemas/swagger-2.0.json",
+	"3.0": "schemas/openapi-3.0.json",
+}, "schemas/json-schema-draft-04.json")
+
+// jsonSchema validates JSON values against a JSON Schema draft 4, the dialect of the
+// Swagger 2.0 and OpenAPI 3.0 schemas. Formats are not checked.
+type jsonSchema struct {
+	root interface{}
+	// docs holds the schema documents by id, for the references between them
+	docs     map[string]interface{}
+	patterns map[string]*regexp.Regexp
+}
+
+// schemaError is a mismatch between a value and a schema.
+type schemaError struct {
+	pointer string
+	message string
+	// enum holds the allowed values of an enum mismatch with the value found
+	enum  []interface{}
+	found interface{}
+}
+
+// loadSchemas returns the embedded schemas by version, leaving out the missing ones.
+func loadSchemas(files map[string]string, metaSchemas ...string) map[string]*jsonSchema {
+	docs := make(map[string]interface{})
+	patterns := make(map[string]*regexp.Regexp)
+
+	load := func(file string) (interface{}, bool) {
+		content, err := schemaFS.ReadFile(file)
+		if err != nil {
+			return nil, false
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(content))
+		dec.UseNumber()
+
+		var doc interface{}
+		if err := dec.Decode(&doc); err != nil {
+			panic(fmt.Sprintf("%s: %v", file, err))
+		}
+
+		if o, ok := doc.(map[string]interface{}); ok {
+			if id, ok := o["id"].(string); ok {
+				docs[strings.TrimSuffix(id, "#")] = doc
+			}
+		}
+
+		compilePatterns(doc, patterns)
+
+		return doc, true
+	}
+
+	for _, file := range metaSchemas {
+		load(file)
+	}
+
+	schemas := make(map[string]*jsonSchema, len(files))
+
+	for version, file := range files {
+		if root, ok := load(file); ok {
+			schemas[version] = &jsonSchema{root: root, docs: docs, patterns: patterns}
+		}
+	}
+
+	return schemas
+}
+
+// compilePatterns adds the regular expressions of schema to patterns.
+func compilePatterns(schema interface{}, patterns map[string]*regexp.Regexp) {
+	add := func(pattern string) {
+		if re, err := regexp.Compile(pattern); err == nil {
+			patterns[pattern] = re
+		}
+	}
+
+	switch s := schema.(type) {
+	case map[string]interface{}:
+		if pattern, ok := s["pattern"].(string); ok {
+			add(pattern)
+		}
+
+		if props, ok := s["patternProperties"].(map[string]interface{}); ok {
+			for pattern := range props {
+				add(pattern)
+			}
+		}
+
+		for _, child := range s {
+			compilePatterns(child, patterns)
+		}
+	case []interface{}:
+		for _, child := range s {
+			compilePatterns(child, patterns)
+		}
+	}
+}
+
+// validate returns the mismatches between value and the schema.
+func (s *jsonSchema) validate(value interface{}) []schemaError {
+	return s.check(s.root, s.root, value, "")
+}
+
+// check returns the mismatches between the value at pointer and schema, a part of
+// the schema document root.
+func (s *jsonSchema) check(root, schema, value interface{}, pointer string) []schemaError {
+	sch, ok := schema.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	if ref, ok := sch["$ref"].(string); ok {
+		refRoot, resolved, ok := s.resolve(root, ref)
+		if !ok {
+			return []schemaError{{pointer: pointer, message: fmt.Sprintf("unresolved schema reference %q", ref)}}
+		}
+
+		return s.check(refRoot, resolved, value, pointer)
+	}
+
+	fail := func(format string, args ...interface{}) []schemaError {
+		return []schemaError{{pointer: pointer, message: fmt.Sprintf(format, args...)}}
+	}
+
+	if types, ok := sch["type"]; ok && !hasType(value, types) {
+		return fail("expected %s, found %s", typeNames(types), jsonType(value))
+	}
+
+	if enum, ok := sch["enum"].([]interface{}); ok && !containsJSON(enum, value) {
+		return []schemaError{enumError(pointer, value, enum)}
+	}
+
+	var errs []schemaError
+
+	for _, k := range []string{"allOf", "anyOf", "oneOf"} {
+		subs, ok := sch[k].([]interface{})
+		if !ok {
+			continue
+		}
+
+		var (
+			matches int
+			closest []schemaError
+		)
+
+		for _, sub := range subs {
+			subErrs := s.check(root, sub, value, pointer)
+
+			switch {
+			case len(subErrs) == 0:
+				matches++
+			case k == "allOf":
+				errs = append(errs, subErrs...)
+			case closest == nil || len(subErrs) < len(closest):
+				// the schema the value is the closest to is most likely the intended one
+				closest = subErrs
+			case len(subErrs) == 1 && len(closest) == 1:
+				// schemas which only differ by the values of a property, such as the
+				// parameter locations, are reported as one enum
+				closest = mergeEnumErrors(closest[0], subErrs[0])
+			}
+		}
+
+		switch {
+		case k == "allOf":
+		case matches == 0:
+			errs = append(errs, closest...)
+		case k == "oneOf" && matches > 1:
+			errs = append(errs, fail("value matches %d schemas of oneOf", matches)...)
+		}
+	}
+
+	if not, ok := sch["not"]; ok && len(s.check(root, not, value, pointer)) == 0 {
+		errs = append(errs, fail("value matches a forbidden schema")...)
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		errs = append(errs, s.checkObject(root, sch, v, pointer)...)
+	case []interface{}:
+		errs = append(errs, s.checkArray(root, sch, v, pointer)...)
+	case string:
+		errs = append(errs, s.checkString(sch, v, pointer)...)
+	case json.Number:
+		errs = append(errs, checkNumber(sch, v, pointer)...)
+	}
+
+	return errs
+}
+
+func (s *jsonSchema) checkObject(root interface{}, sch, object map[string]interface{}, pointer string) []schemaError {
+	var errs []schemaError
+
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, schemaError{pointer: pointer, message: fmt.Sprintf(format, args...)})
+	}
+
+	if n, ok := schemaInt(sch["minProperties"]); ok && len(object) < n {
+		fail("expected at least %d properties, found %d", n, len(object))
+	}
+
+	if n, ok := schemaInt(sch["maxProperties"]); ok && len(object) > n {
+		fail("expected at most %d properties, found %d", n, len(object))
+	}
+
+	required, _ := sch["required"].([]interface{})
+	for _, name := range required {
+		if name, ok := name.(string); ok {
+			if _, ok := object[name]; !ok {
+				fail("missing property %q", name)
+			}
+		}
+	}
+
+	dependencies, _ := sch["dependencies"].(map[string]interface{})
+	for _, name := range sortedKeys(dependencies) {
+		if _, ok := object[name]; !ok {
+			continue
+		}
+
+		if names, ok := dependencies[name].([]interface{}); ok {
+			for _, dep := range names {
+				if _, ok := object[fmt.Sprint(dep)]; !ok {
+					fail("property %q requires property %q", name, dep)
+				}
+			}
+		} else {
+			errs = append(errs, s.check(root, dependencies[name], object, pointer)...)
+		}
+	}
+
+	props, _ := sch["properties"].(map[string]interface{})
+	patternProps, _ := sch["patternProperties"].(map[string]interface{})
+
+	for _, name := range sortedKeys(object) {
+		propPointer := pointer + "/" + escapePointer(name)
+		matched := false
+
+		if prop, ok := props[name]; ok {
+			matched = true
+			errs = append(errs, s.check(root, prop, object[name], propPointer)...)
+		}
+
+		for _, pattern := range sortedKeys(patternProps) {
+			if re := s.patterns[pattern]; re != nil && re.MatchString(name) {
+				matched = true
+				errs = append(errs, s.check(root, patternProps[pattern], object[name], propPointer)...)
+			}
+		}
+
+		if matched {
+			continue
+		}
+
+		switch additional := sch["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				fail("property %q is not allowed", name)
+			}
+		case map[string]interface{}:
+			errs = append(errs, s.check(root, additional, object[name], propPointer)...)
+		}
+	}
+
+	return errs
+}
+
+func (s *jsonSchema) checkArray(root interface{}, sch map[string]interface{}, array []interface{}, pointer string) []schemaError {
+	var errs []schemaError
+
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, schemaError{pointer: pointer, message: fmt.Sprintf(format, args...)})
+	}
+
+	if n, ok := schemaInt(sch["minItems"]); ok && len(array) < n {
+		fail("expected at least %d items, found %d", n, len(array))
+	}
+
+	if n, ok := schemaInt(sch["maxItems"]); ok && len(array) > n {
+		fail("expected at most %d items, found %d", n, len(array))
+	}
+
+	if unique, _ := sch["uniqueItems"].(bool); unique {
+	items:
+		for i := range array {
+			for j := 0; j < i; j++ {
+				if jsonEqual(array[i], array[j]) {
+					fail("items %d and %d are equal", j, i)
+
+					break items
+				}
+			}
+		}
+	}
+
+	for i, item := range array {
+		itemPointer := fmt.Sprintf("%s/%d", pointer, i)
+
+		switch items := sch["items"].(type) {
+		case map[string]interface{}:
+			errs = append(errs, s.check(root, items, item, itemPointer)...)
+		case []interface{}:
+			if i < len(items) {
+				errs = append(errs, s.check(root, items[i], item, itemPointer)...)
+
+				continue
+			}
+
+			switch additional := sch["additionalItems"].(type) {
+			case bool:
+				if !additional {
+					fail("item %d is not allowed", i)
+				}
+			case map[string]interface{}:
+				errs = append(errs, s.check(root, additional, item, itemPointer)...)
+			}
+		}
+	}
+
+	return errs
+}
+
+func (s *jsonSchema) checkString(sch map[string]interface{}, str, pointer string) []schemaError {
+	var errs []schemaError
+
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, schemaError{pointer: pointer, message: fmt.Sprintf(format, args...)})
+	}
+
+	length := utf8.RuneCountInString(str)
+
+	if n, ok := schemaInt(sch["minLength"]); ok && length < n {
+		fail("expected at least %d characters, found %d", n, length)
+	}
+
+	if n, ok := schemaInt(sch["maxLength"]); ok && length > n {
+		fail("expected at most %d characters, found %d", n, length)
+	}
+
+	if pattern, ok := sch["pattern"].(string); ok {
+		if re := s.patterns[pattern]; re != nil && !re.MatchString(str) {
+			fail("value %q does not match pattern %q", str, pattern)
+		}
+	}
+
+	return errs
+}
+
+func checkNumber(sch map[string]interface{}, n json.Number, pointer string) []schemaError {
+	var errs []schemaError
+
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, schemaError{pointer: pointer, message: fmt.Sprintf(format, args...)})
+	}
+
+	f, _ := n.Float64()
+
+	if min, ok := jsonFloat(sch["minimum"]); ok {
+		if exclusive, _ := sch["exclusiveMinimum"].(bool); exclusive && f <= min {
+			fail("expected a value greater than %v, found %s", min, n)
+		} else if f < min {
+			fail("expected a value of at least %v, found %s", min, n)
+		}
+	}
+
+	if max, ok := jsonFloat(sch["maximum"]); ok {
+		if exclusive, _ := sch["exclusiveMaximum"].(bool); exclusive && f >= max {
+			fail("expected a value less than %v, found %s", max, n)
+		} else if f > max {
+			fail("expected a value of at most %v, found %s", max, n)
+		}
+	}
+
+	if divisor, ok := sch["multipleOf"].(json.Number); ok {
+		v, okV := new(big.Rat).SetString(n.String())
+		d, okD := new(big.Rat).SetString(divisor.String())
+
+		if okV && okD && d.Sign() != 0 && !new(big.Rat).Quo(v, d).IsInt() {
+			fail("expected a multiple of %s, found %s", divisor, n)
+		}
+	}
+
+	return errs
+}
+
+func enumError(pointer string, value interface{}, enum []interface{}) schemaError {
+	return schemaError{
+		pointer: pointer,
+		message: fmt.Sprintf("value %s is not one of %s", encodeJSON(value), encodeJSON(enum)),
+		enum:    enum,
+		found:   value,
+	}
+}
+
+// mergeEnumErrors returns the enum mismatch of a and b at the same pointer as one error,
+// or a if they are not.
+func mergeEnumErrors(a, b schemaError) []schemaError {
+	if a.enum == nil || b.enum == nil || a.pointer != b.pointer {
+		return []schemaError{a}
+	}
+
+	enum := append([]interface{}(nil), a.enum...)
+
+	for _, v := range b.enum {
+		if !containsJSON(enum, v) {
+			enum = append(enum, v)
+		}
+	}
+
+	return []schemaError{enumError(a.pointer, a.found, enum)}
+}
+
+// resolve returns the schema document and the schema referenced by ref from root.
+func (s *jsonSchema) resolve(root interface{}, ref string) (interface{}, interface{}, bool) {
+	id, fragment := ref, ""
+	if i := strings.IndexByte(ref, '#'); i >= 0 {
+		id, fragment = ref[:i], ref[i:]
+	}
+
+	if id != "" {
+		var ok bool
+		if root, ok = s.docs[id]; !ok {
+			return nil, nil, false
+		}
+	}
+
+	resolved, ok := resolvePointer(root, fragment)
+
+	return root, resolved, ok
+}
+
+// hasType reports whether value has one of types, a JSON Schema type or a list of them.
+func hasType(value, types interface{}) bool {
+	switch t := types.(type) {
+	case string:
+		return t == jsonType(value) || t == "number" && jsonType(value) == "integer"
+	case []interface{}:
+		for _, t := range t {
+			if hasType(value, t) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
+
+// jsonType returns the JSON Schema type of value, integer for integral numbers.
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if f, err := v.Float64(); err == nil && f == math.Trunc(f) {
+			return "integer"
+		}
+
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+
+	return fmt.Sprintf("%T", value)
+}
+
+func typeNames(types interface{}) string {
+	if list, ok := types.([]interface{}); ok {
+		names := make([]string, len(list))
+		for i, t := range list {
+			names[i] = fmt.Sprint(t)
+		}
+
+		return strings.Join(names, " or ")
+	}
+
+	return fmt.Sprint(types)
+}
+
+func containsJSON(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if jsonEqual(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// jsonEqual reports whether the JSON values a and b are equal, numbers being compared by value.
+func jsonEqual(a, b interface{}) bool {
+	if fa, ok := jsonFloat(a); ok {
+		fb, ok := jsonFloat(b)
+
+		return ok && fa == fb
+	}
+
+	switch a := a.(type) {
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+
+		for k, v := range a {
+			if w, ok := b[k]; !ok || !jsonEqual(v, w) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+
+		for i := range a {
+			if !jsonEqual(a[i], b[i]) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+func jsonFloat(v interface{}) (float64, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+
+	f, err := n.Float64()
+
+	return f, err == nil
+}
+
+func schemaInt(v interface{}) (int, bool) {
+	f, ok := jsonFloat(v)
+
+	return int(f), ok
+}
+
+func encodeJSON(v interface{}) string {
+	b, _ := json.Marshal(v)
+
+	return string(b)
+}
@@ -0,0 +1,111 @@
+package httpSwagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeJSON(t *testing.T, src string) interface{} {
+	dec := json.NewDecoder(bytes.NewReader([]byte(src)))
+	dec.UseNumber()
+
+	var v interface{}
+	require.NoError(t, dec.Decode(&v))
+
+	return v
+}
+
+func TestJSONSchema(t *testing.T) {
+	root := decodeJSON(t, `{
+		"type":"object",
+		"required":["name"],
+		"additionalProperties":false,
+		"properties":{
+			"name":{"type":"string","minLength":1,"pattern":"^[a-z]+$"},
+			"size":{"type":"number","minimum":1,"exclusiveMaximum":true,"maximum":10,"multipleOf":0.5},
+			"tags":{"type":"array","items":{"$ref":"#/definitions/tag"},"uniqueItems":true,"maxItems":2},
+			"in":{"oneOf":[{"enum":["query"]},{"enum":["header"]}]},
+			"kind":{"anyOf":[{"type":"string"},{"type":"boolean"}]},
+			"meta":{"not":{"type":"null"}}
+		},
+		"patternProperties":{"^x-":{}},
+		"dependencies":{"size":["tags"]},
+		"definitions":{"tag":{"type":"string"}}
+	}`)
+	patterns := make(map[string]*regexp.Regexp)
+	compilePatterns(root, patterns)
+
+	schema := &jsonSchema{root: root, patterns: patterns}
+
+	tests := []struct {
+		desc  string
+		value string
+		exp   []schemaError
+	}{
+		{
+			desc:  "valid",
+			value: `{"name":"pets","size":2.5,"tags":["a","b"],"in":"query","kind":true,"meta":{},"x-any":null}`,
+		},
+		{
+			desc:  "type",
+			value: `[]`,
+			exp:   []schemaError{{pointer: "", message: "expected object, found array"}},
+		},
+		{
+			desc:  "object",
+			value: `{"size":1,"other":1}`,
+			exp: []schemaError{
+				{pointer: "", message: `missing property "name"`},
+				{pointer: "", message: `property "size" requires property "tags"`},
+				{pointer: "", message: `property "other" is not allowed`},
+			},
+		},
+		{
+			desc:  "values",
+			value: `{"name":"Pets","size":10,"tags":["a","a",1],"kind":1,"meta":null}`,
+			exp: []schemaError{
+				{pointer: "/kind", message: "expected string, found integer"},
+				{pointer: "/meta", message: "value matches a forbidden schema"},
+				{pointer: "/name", message: `value "Pets" does not match pattern "^[a-z]+$"`},
+				{pointer: "/size", message: "expected a value less than 10, found 10"},
+				{pointer: "/tags", message: "expected at most 2 items, found 3"},
+				{pointer: "/tags", message: "items 0 and 1 are equal"},
+				{pointer: "/tags/2", message: "expected string, found integer"},
+			},
+		},
+		{
+			desc:  "enums",
+			value: `{"name":"pets","size":1.2,"tags":[],"in":"cookie"}`,
+			exp: []schemaError{
+				{pointer: "/in", message: `value "cookie" is not one of ["query","header"]`},
+				{pointer: "/size", message: "expected a multiple of 0.5, found 1.2"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			errs := schema.validate(decodeJSON(t, test.value))
+			for i := range errs {
+				errs[i].enum, errs[i].found = nil, nil
+			}
+
+			assert.Equal(t, test.exp, errs)
+		})
+	}
+}
+
+func TestDocumentSchemas(t *testing.T) {
+	schema := documentSchemas["2.0"]
+	require.NotNil(t, schema)
+
+	assert.Empty(t, schema.validate(decodeJSON(t, `{"swagger":"2.0","info":{"title":"Petstore","version":"1.0"},
+		"paths":{"/pets":{"get":{"parameters":[{"name":"limit","in":"query","type":"integer"}],
+			"responses":{"200":{"description":"ok","schema":{"type":"array","items":{"$ref":"#/definitions/Pet"}}}}}}},
+		"definitions":{"Pet":{"type":"object","required":["name"],"properties":{"name":{"type":"string"}}}}}`)))
+}
@@ -0,0 +1,19 @@
+// Reloads the documentation when the hot-reload event stream reports a changed definition.
+(function () {
+  if (!window.EventSource) {
+    return;
+  }
+
+  var source = new EventSource("./hot-reload");
+
+  source.addEventListener("reload", function () {
+    var ui = window.ui;
+    if (ui && ui.specActions && ui.specSelectors) {
+      ui.specActions.download(ui.specSelectors.url());
+
+      return;
+    }
+
+    window.location.reload();
+  });
+})();
@@ -0,0 +1,67 @@
+// Applies the color theme of the index page, set in the data-theme-mode attribute
+// of the root element, and renders the theme switcher when enabled.
+(function () {
+  var storageKey = "swagger-ui-theme";
+  var root = document.documentElement;
+  var script = document.currentScript;
+  var switcher = script !== null && script.hasAttribute("data-switcher");
+  var media = window.matchMedia ? window.matchMedia("(prefers-color-scheme: dark)") : null;
+
+  function storedMode() {
+    try {
+      return window.localStorage.getItem(storageKey);
+    } catch (e) {
+      return null;
+    }
+  }
+
+  function resolve() {
+    var mode = (switcher && storedMode()) || root.getAttribute("data-theme-mode") || "light";
+    if (mode === "system") {
+      return media !== null && media.matches ? "dark" : "light";
+    }
+
+    return mode === "dark" ? "dark" : "light";
+  }
+
+  function apply() {
+    root.setAttribute("data-theme", resolve());
+  }
+
+  apply();
+
+  if (media !== null && media.addEventListener) {
+    media.addEventListener("change", apply);
+  }
+
+  if (!switcher) {
+    return;
+  }
+
+  document.addEventListener("DOMContentLoaded", function () {
+    var button = document.createElement("button");
+    button.type = "button";
+    button.className = "swagger-ui-theme-switcher";
+
+    function update() {
+      var dark = root.getAttribute("data-theme") === "dark";
+      button.textContent = dark ? "Light theme" : "Dark theme";
+      button.setAttribute("aria-pressed", String(dark));
+    }
+
+    button.addEventListener("click", function () {
+      var next = root.getAttribute("data-theme") === "dark" ? "light" : "dark";
+      try {
+        window.localStorage.setItem(storageKey, next);
+      } catch (e) {
+        // the choice only lasts for the page
+      }
+
+      root.setAttribute("data-theme", next);
+      update();
+    });
+
+    update();
+    document.body.appendChild(button);
+  });
+})();
@@ -1,11 +1,16 @@
 package httpSwagger
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"html/template"
+	"io/fs"
+	"log"
 	"net/http"
-	"net/url"
 	"path/filepath"
-	"regexp"
+	"strings"
+	"time"
 
 	swaggerFiles "github.com/swaggo/files/v2"
 	"github.com/swaggo/swag"
@@ -30,6 +35,125 @@ type Config struct {
 	Layout                   SwaggerLayout
 	DefaultModelsExpandDepth ModelsExpandDepthType
 	ShowExtensions           bool
+	// URLs lists the API definitions offered in the Swagger UI top-bar selector.
+	URLs []SpecURL
+	// PrimaryName is the name of the definition in URLs selected on page load.
+	PrimaryName string
+	// DocProvider provides the document served at `doc.json`. Defaults to the swag instance InstanceName.
+	DocProvider DocProvider
+	// CachePolicy holds the Cache-Control header values sent for each class of resource.
+	CachePolicy CachePolicy
+	// AssetsModTime is the Last-Modified time of the embedded Swagger UI assets.
+	AssetsModTime time.Time
+	// Encodings lists the content encodings the assets and API definitions are served with, in order of preference.
+	Encodings []Encoding
+	// Prefix is the path prefix the handler is mounted at. Detected from each request when empty.
+	Prefix string
+	// Filter enables filtering the operations by tag.
+	Filter bool
+	// FilterExpression pre-fills the tag filter.
+	FilterExpression string
+	// TryItOutEnabled enables "Try it out" for all operations by default.
+	TryItOutEnabled bool
+	// SupportedSubmitMethods lists the HTTP methods "Try it out" is enabled for. All of them when nil.
+	SupportedSubmitMethods []SubmitMethod
+	// DisplayRequestDuration displays the duration of "Try it out" requests.
+	DisplayRequestDuration bool
+	// DisplayOperationID displays the operationId of operations.
+	DisplayOperationID bool
+	// DefaultModelExpandDepth is the default expansion depth of the model in the operations.
+	DefaultModelExpandDepth *int
+	// DefaultModelRendering controls how models are shown when the API is first rendered.
+	DefaultModelRendering ModelRendering
+	// MaxDisplayedTags limits the number of tagged operations displayed.
+	MaxDisplayedTags int
+	// ShowCommonExtensions displays the extension fields and values of parameters.
+	ShowCommonExtensions bool
+	// SyntaxHighlight controls the syntax highlighting of request and response bodies.
+	SyntaxHighlight *SyntaxHighlightConfig
+	// RequestSnippetsEnabled displays code snippets for "Try it out" requests.
+	RequestSnippetsEnabled bool
+	// OperationsSorter sorts the operations of each API.
+	OperationsSorter OperationsSorterType
+	// TagsSorter sorts the tags.
+	TagsSorter TagsSorterType
+	// ValidatorURL is the URL of the validator badge. No validation is performed when empty.
+	ValidatorURL string
+	// WithCredentials sends credentials with the CORS requests of "Try it out".
+	WithCredentials bool
+	// Title is the title of the index page, `Swagger UI` when empty.
+	Title string
+	// Favicon32 and Favicon16 are the URLs of the icons of the index page, the
+	// embedded Swagger UI ones when empty.
+	Favicon32 string
+	Favicon16 string
+	// Stylesheets are the URLs of additional stylesheets of the index page.
+	Stylesheets []string
+	// CustomCSS holds CSS rules added to the inline style of the index page.
+	CustomCSS template.CSS
+	// HeadHTML holds content added at the end of the head of the index page.
+	HeadHTML template.HTML
+	// Files holds files served next to the index page.
+	Files fs.FS
+	// IndexTemplate replaces the index page template when not empty.
+	IndexTemplate string
+	// Transformers are applied in order to the served documents.
+	Transformers []DocTransformer
+	// Audience filters the served documents per caller.
+	Audience *AudienceConfig
+	// Validation controls the validation of the documents when the handler is created.
+	Validation ValidationMode
+	// HotReload is the interval the documents are polled at to reload open index pages, zero to disable.
+	HotReload time.Duration
+	// Proxy makes the handler fetch the remote documents itself when not nil.
+	Proxy *RemoteConfig
+	// Renderer is the documentation renderer of the index page, Swagger UI when empty.
+	Renderer RendererType
+	// ReDoc, RapiDoc and Elements hold the options of the respective renderers.
+	ReDoc    *ReDocConfig
+	RapiDoc  *RapiDocConfig
+	Elements *ElementsConfig
+	// RendererAssets is the base URL the assets of the renderers other than Swagger UI are loaded from.
+	RendererAssets string
+	// RendererIntegrity holds the Subresource Integrity hashes of the renderer assets, by name.
+	RendererIntegrity map[string]string
+	// Theme is the color theme of the index page, the Swagger UI one when empty.
+	Theme ThemeMode
+	// ThemeSwitcher renders a button switching between the light and dark themes.
+	ThemeSwitcher bool
+	// StrictCSP serves the index page with a nonce-based Content-Security-Policy header.
+	StrictCSP bool
+	// ExternalInitializer loads Swagger UI from `swagger-initializer.js` instead of an inline script.
+	ExternalInitializer bool
+	// OAuth holds the OAuth2 settings of Swagger UI.
+	OAuth *OAuthConfig
+	// RewriteServer rewrites the host, schemes and basePath of the served documents to match each request.
+	RewriteServer bool
+	// TrustedProxies lists the IP addresses and CIDR ranges of the proxies whose forwarding headers are honored.
+	TrustedProxies []string
+	// OpenAPIVersion enables serving the Swagger 2.0 document converted to OpenAPI 3 at `openapi.json`.
+	OpenAPIVersion OpenAPIVersion
+
+	// errs holds the problems found while applying the configuration options.
+	errs []error
+	// assetIntegrity holds the Subresource Integrity hashes of the embedded renderer assets.
+	assetIntegrity map[string]string
+	// unavailable lists the services of a Portal which failed their last refresh.
+	unavailable []string
+}
+
+// SpecURL describes an API definition listed in the Swagger UI top-bar selector.
+type SpecURL struct {
+	// Name is the label shown in the definition selector.
+	Name string `json:"name"`
+	// URL points to the API definition. It defaults to `<InstanceName>.json` served by this handler.
+	URL string `json:"url"`
+	// InstanceName is the swag instance served by this handler when URL is empty.
+	InstanceName string `json:"-"`
+	// Provider replaces the swag registry lookup of InstanceName.
+	Provider DocProvider `json:"-"`
+	// Audiences restricts the definition to the callers with one of them, see Audience.
+	Audiences []string `json:"-"`
 }
 
 // URL presents the url pointing to API definition (normally swagger.json or swagger.yaml).
@@ -76,7 +200,9 @@ func PersistAuthorization(persistAuthorization bool) func(*Config) {
 	}
 }
 
-// Plugins specifies additional plugins to load into Swagger UI.
+// Plugins specifies additional plugins to load into Swagger UI, inserted into the page as raw JavaScript.
+//
+// Deprecated: use PluginNames, which validates the names.
 func Plugins(plugins []string) func(*Config) {
 	return func(c *Config) {
 		vs := make([]template.JS, len(plugins))
@@ -87,7 +213,9 @@ func Plugins(plugins []string) func(*Config) {
 	}
 }
 
-// UIConfig specifies additional SwaggerUIBundle config object properties.
+// UIConfig specifies additional SwaggerUIBundle config object properties, inserted into the page as raw JavaScript.
+//
+// Deprecated: use UIConfigValues, which JSON-encodes the values unless created with RawJS.
 func UIConfig(props map[string]string) func(*Config) {
 	return func(c *Config) {
 		vs := make(map[template.JS]template.JS, len(props))
@@ -150,6 +278,38 @@ func ShowExtensions(showExtensions bool) func(config *Config) {
 	}
 }
 
+// URLs sets the API definitions listed in the Swagger UI top-bar selector.
+// Entries with an InstanceName and no URL are served by the handler at `<InstanceName>.json`.
+func URLs(urls ...SpecURL) func(*Config) {
+	return func(c *Config) {
+		c.URLs = urls
+	}
+}
+
+// PrimaryName sets the name of the definition in URLs selected on page load.
+// It can be overridden per request with the `urls.primaryName` query parameter.
+func PrimaryName(name string) func(*Config) {
+	return func(c *Config) {
+		c.PrimaryName = name
+	}
+}
+
+// Provider sets the DocProvider of the document served at `doc.json`, replacing
+// the lookup of InstanceName in the swag registry.
+func Provider(p DocProvider) func(*Config) {
+	return func(c *Config) {
+		c.DocProvider = p
+	}
+}
+
+// OpenAPI3 serves the Swagger 2.0 document converted to the given OpenAPI 3 version
+// at `openapi.json` and `openapi.yaml`.
+func OpenAPI3(version OpenAPIVersion) func(*Config) {
+	return func(c *Config) {
+		c.OpenAPIVersion = version
+	}
+}
+
 func newConfig(configFns ...func(*Config)) *Config {
 	config := Config{
 		URL:                      "doc.json",
@@ -171,81 +331,406 @@ func newConfig(configFns ...func(*Config)) *Config {
 		config.InstanceName = swag.Name
 	}
 
+	if config.Proxy != nil && config.DocProvider == nil && isRemoteURL(config.URL) {
+		config.DocProvider = RemoteProvider(config.URL, *config.Proxy)
+		config.URL = "doc.json"
+	}
+
+	if config.DocProvider == nil {
+		config.DocProvider = SwagProvider(config.InstanceName)
+	}
+
+	urls := make([]SpecURL, len(config.URLs))
+	for i, u := range config.URLs {
+		if config.Proxy != nil && u.InstanceName == "" && u.Provider == nil && isRemoteURL(u.URL) {
+			u.InstanceName = proxyName(u.Name, i)
+			u.Provider = RemoteProvider(u.URL, *config.Proxy)
+			u.URL = ""
+		}
+		if u.URL == "" && u.InstanceName != "" {
+			u.URL = u.InstanceName + ".json"
+		}
+		urls[i] = u
+	}
+	config.URLs = urls
+
+	if config.AssetsModTime.IsZero() {
+		config.AssetsModTime = executableModTime()
+	}
+
 	return &config
 }
 
-// Handler wraps `http.Handler` into `http.HandlerFunc`.
+// Handler wraps `http.Handler` into `http.HandlerFunc`. It answers GET and HEAD requests.
+// It never fails: problems reported by the options, e.g. an invalid RewriteServer
+// proxy or IndexTemplate, are logged with the standard logger and make `index.html`,
+// `swagger-config.json` and `swagger-initializer.js` respond with 500 Internal
+// Server Error, the documents and assets being still served. Use NewHandler to
+// validate the whole configuration up front.
 func Handler(configFns ...func(*Config)) http.HandlerFunc {
+	h := newHandler(newConfig(configFns...))
+	if h.err != nil {
+		log.Printf("httpSwagger: %v", h.err)
+	}
+
+	return h.ServeHTTP
+}
 
-	config := newConfig(configFns...)
+type handler struct {
+	config      *Config
+	err         error
+	index       *template.Template
+	initializer []byte
+	docs        map[string]docReader
+	audiences   map[string][]string
+	validation  *ValidationReport
+	reloader    *reloader
+	assets      []*assetStore
+	specs       *encodingCache
+}
+
+func newHandler(config *Config) *handler {
+	errs := append([]error(nil), config.errs...)
+
+	index, err := parseIndex(config)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("index template: %w", err))
+	}
+
+	initializer, err := renderInitializer(config)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("initializer: %w", err))
+	}
+
+	if config.assetIntegrity, err = embeddedIntegrity(config); err != nil {
+		errs = append(errs, err)
+	}
+
+	trusted, err := parseTrustedProxies(config.TrustedProxies)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("trusted proxies: %w", err))
+	}
 
-	// create a template with name
-	index, _ := template.New("swagger_index.html").Parse(indexTempl)
+	// sources are the documents served before any per request processing
+	sources := make(map[string]docReader)
 
-	re := regexp.MustCompile(`^(.*/)([^?].*)?[?|.]*$`)
+	docReaderFor := func(name string, p DocProvider) docReader {
+		read := transformDoc(providerDoc(p), config.Transformers)
+		sources[name] = read
+
+		if config.Audience != nil {
+			read = audienceDoc(read, config.Audience)
+		}
+
+		if config.RewriteServer {
+			return rewriteServerDoc(read, trusted)
+		}
+
+		return read
+	}
+
+	docs := map[string]docReader{"doc": docReaderFor("doc", config.DocProvider)}
+	docNames := []string{"doc"}
+	docAudiences := make(map[string][]string)
+
+	for _, u := range config.URLs {
+		if u.InstanceName == "" {
+			continue
+		}
+
+		docAudiences[u.InstanceName] = u.Audiences
+
+		provider := u.Provider
+		if provider == nil {
+			provider = SwagProvider(u.InstanceName)
+		}
+
+		docs[u.InstanceName] = docReaderFor(u.InstanceName, provider)
+		docNames = append(docNames, u.InstanceName)
+	}
+
+	if config.OpenAPIVersion != "" {
+		docs["openapi"] = openAPIDoc(docs["doc"], config.OpenAPIVersion)
+	}
+
+	var validation *ValidationReport
+	if config.Validation != "" {
+		validation = validateDocs(docNames, sources)
+
+		if config.Validation == FailFastValidation {
+			for _, issue := range validation.Issues {
+				errs = append(errs, issue)
+			}
+		}
+	}
+
+	var reload *reloader
+	if config.HotReload > 0 {
+		reload = newReloader(docNames, sources, config.HotReload)
+	}
+
+	// assets are looked up in order, user files first
+	var assets []*assetStore
+	if config.Files != nil {
+		assets = append(assets, newAssetStore(config.Files, config.AssetsModTime, config.Encodings))
+	}
+
+	assets = append(assets,
+		newAssetStore(staticFiles, config.AssetsModTime, config.Encodings),
+		newAssetStore(swaggerFiles.FS, config.AssetsModTime, config.Encodings),
+	)
+
+	return &handler{
+		config:      config,
+		err:         newConfigError(errs),
+		index:       index,
+		initializer: initializer,
+		docs:        docs,
+		audiences:   docAudiences,
+		validation:  validation,
+		reloader:    reload,
+		assets:      assets,
+		specs:       newEncodingCache(config.Encodings),
+	}
+}
+
+// headResponseWriter discards the body of the responses to HEAD requests.
+type headResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (w headResponseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodHead:
+		// answered as GET requests, without body
+		w = headResponseWriter{w}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	path, ok := h.config.resolvePath(r)
+	if !ok {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	switch filepath.Ext(path) {
+	case ".html":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	case ".css":
+		w.Header().Set("Content-Type", "text/css; charset=utf-8")
+	case ".js":
+		w.Header().Set("Content-Type", "application/javascript")
+	case ".png":
+		w.Header().Set("Content-Type", "image/png")
+	case ".json":
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	}
 
-	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	if h.config.Audience != nil {
+		audiences, err := h.config.Audience.Audience(r)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
 
 			return
 		}
 
-		matches := re.FindStringSubmatch(r.RequestURI)
+		r = withAudiences(r, audiences)
+	}
+
+	switch path {
+	case "index.html", "swagger-config.json", "swagger-initializer.js":
+		// fail instead of rendering a broken page, the problems being logged by Handler
+		if h.err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		}
+	}
 
-		path := matches[2]
+	switch path {
+	case "index.html":
+		h.serveIndex(w, r)
+	case "swagger-config.json":
+		body, _ := h.config.forRequest(r).swaggerConfig().MarshalJSON()
+		serveBytes(w, r, path, time.Time{}, body, h.cacheControl(h.config.CachePolicy.Index))
+	case "swagger-initializer.js":
+		// always served in place of the initializer of the Swagger UI assets, which
+		// only renders the Petstore
+		serveBytes(w, r, path, time.Time{}, h.initializer, h.config.CachePolicy.Index)
+	case "hot-reload":
+		if h.reloader == nil {
+			http.NotFound(w, r)
 
-		switch filepath.Ext(path) {
-		case ".html":
-			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			return
+		}
 
-		case ".css":
-			w.Header().Set("Content-Type", "text/css; charset=utf-8")
-		case ".js":
-			w.Header().Set("Content-Type", "application/javascript")
-		case ".png":
-			w.Header().Set("Content-Type", "image/png")
-		case ".json":
-			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		h.reloader.serve(w, r)
+	case "validation.json":
+		validation := h.reportedValidation()
+		if validation == nil {
+			http.NotFound(w, r)
+
+			return
 		}
 
-		switch path {
-		case "index.html":
-			_ = index.Execute(w, config)
-		case "doc.json":
-			doc, err := swag.ReadDoc(config.InstanceName)
-			if err != nil {
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		body, _ := json.Marshal(validation)
+		serveBytes(w, r, path, time.Time{}, body, h.config.CachePolicy.Index)
+	case "":
+		redirectToIndex(w, r)
+	default:
+		ext := filepath.Ext(path)
+		name := strings.TrimSuffix(path, ext)
+
+		if read, ok := h.docs[name]; ok && entitled(h.audiences[name], requestAudiences(r)) {
+			switch ext {
+			case ".json":
+				h.serveDoc(w, r, path, read, negotiateFormat(r, jsonFormat))
+
+				return
+			case ".yaml":
+				h.serveDoc(w, r, path, read, negotiateFormat(r, yamlFormat))
 
 				return
 			}
+		}
+
+		h.serveAsset(w, r, path)
+	}
+}
 
-			_, _ = w.Write([]byte(doc))
-		case "":
-			http.Redirect(w, r, matches[1]+"/"+"index.html", http.StatusMovedPermanently)
-		default:
-			var err error
-			r.URL, err = url.Parse(matches[2])
-			if err != nil {
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+// serveAsset serves path from the first asset store holding it.
+func (h *handler) serveAsset(w http.ResponseWriter, r *http.Request, path string) {
+	last := len(h.assets) - 1
+	for _, s := range h.assets[:last] {
+		if s.contains(path) {
+			s.serve(w, r, path, h.config.CachePolicy.Assets)
 
-				return
+			return
+		}
+	}
+
+	h.assets[last].serve(w, r, path, h.config.CachePolicy.Assets)
+}
+
+func (h *handler) serveIndex(w http.ResponseWriter, r *http.Request) {
+	data := IndexData{Config: h.config.forRequest(r), Validation: h.reportedValidation()}
+
+	if h.config.StrictCSP {
+		nonce, err := newNonce()
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		}
+
+		data.Nonce = nonce
+		w.Header().Set("Content-Security-Policy", contentSecurityPolicy(nonce))
+	}
+
+	var buf bytes.Buffer
+	if err := h.index.Execute(&buf, data); err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	serveBytes(w, r, "index.html", time.Time{}, buf.Bytes(), h.cacheControl(h.config.CachePolicy.Index))
+}
+
+func (h *handler) serveDoc(w http.ResponseWriter, r *http.Request, name string, read docReader, format docFormat) {
+	w.Header().Add("Vary", "Accept")
+
+	body, err := read(r)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	if format == yamlFormat {
+		if body, err = jsonToYAML(body); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", format.contentType())
+
+	key := strings.TrimSuffix(name, filepath.Ext(name)) + format.contentType()
+	serveEncoded(w, r, "", time.Time{}, h.specs.get(key, body), h.cacheControl(h.config.CachePolicy.Spec))
+}
+
+// cacheControl returns the Cache-Control header value of the responses which depend
+// on the audiences of the caller, given the configured one.
+func (h *handler) cacheControl(cacheControl string) string {
+	if h.config.Audience == nil {
+		return cacheControl
+	}
+
+	return privateCacheControl(cacheControl)
+}
+
+// forRequest returns the configuration used to render the index page for r.
+func (c *Config) forRequest(r *http.Request) *Config {
+	cfg := *c
+	if c.Audience != nil {
+		cfg.URLs = visibleURLs(c.URLs, requestAudiences(r))
+	}
+
+	// the requested definition, else the configured one, provided they are listed
+	primary := ""
+
+	for _, name := range []string{cfg.PrimaryName, r.URL.Query().Get("urls.primaryName")} {
+		for _, u := range cfg.URLs {
+			if name != "" && u.Name == name {
+				primary = name
 			}
-			http.FileServer(http.FS(swaggerFiles.FS)).ServeHTTP(w, r)
 		}
 	}
+
+	cfg.PrimaryName = primary
+
+	return &cfg
+}
+
+// docReader reads the API definition served for a request as JSON.
+type docReader func(r *http.Request) ([]byte, error)
+
+func openAPIDoc(read docReader, version OpenAPIVersion) docReader {
+	return func(r *http.Request) ([]byte, error) {
+		doc, err := read(r)
+		if err != nil {
+			return nil, err
+		}
+
+		return convertToOpenAPI3(doc, version)
+	}
 }
 
 const indexTempl = `<!-- HTML for static distribution bundle build -->
 <!DOCTYPE html>
-<html lang="en">
+<html lang="en"{{with .Theme}} data-theme-mode="{{.}}"{{end}}>
 <head>
   <meta charset="UTF-8">
-  <title>Swagger UI</title>
+  <title>{{with .Title}}{{.}}{{else}}Swagger UI{{end}}</title>
   <link rel="stylesheet" type="text/css" href="./swagger-ui.css" >
-  <link rel="icon" type="image/png" href="./favicon-32x32.png" sizes="32x32" />
-  <link rel="icon" type="image/png" href="./favicon-16x16.png" sizes="16x16" />
-  <style>
+  {{- range .Stylesheets}}
+  <link rel="stylesheet" type="text/css" href="{{.}}"{{if $.Nonce}} nonce="{{$.Nonce}}"{{end}} >
+  {{- end}}
+  <link rel="icon" type="image/png" href="{{with .Favicon32}}{{.}}{{else}}./favicon-32x32.png{{end}}" sizes="32x32" />
+  <link rel="icon" type="image/png" href="{{with .Favicon16}}{{.}}{{else}}./favicon-16x16.png{{end}}" sizes="16x16" />
+  <style{{if .Nonce}} nonce="{{.Nonce}}"{{end}}>
     html
     {
         box-sizing: border-box;
@@ -263,7 +748,40 @@ const indexTempl = `<!-- HTML for static distribution bundle build -->
       margin:0;
       background: #fafafa;
     }
+    {{- if .ValidationFailed}}
+    .validation-banner
+    {
+        padding: 10px 20px;
+        background: #fff3cd;
+        color: #664d03;
+        font-family: sans-serif;
+        font-size: 14px;
+    }
+    {{- end}}
+    {{- if .UnavailableServices}}
+    .availability-banner
+    {
+        padding: 10px 20px;
+        background: #f8d7da;
+        color: #58151c;
+        font-family: sans-serif;
+        font-size: 14px;
+    }
+    {{- end}}
+    {{- with .CustomCSS}}
+    {{.}}
+    {{- end}}
   </style>
+  {{- if or .Theme .ThemeSwitcher}}
+  <link rel="stylesheet" type="text/css" href="./swagger-ui-dark.css" >
+  <script src="./swagger-ui-theme.js"{{if .ThemeSwitcher}} data-switcher{{end}}> </script>
+  {{- end}}
+  {{- if .HotReload}}
+  <script src="./hot-reload.js"{{if .Nonce}} nonce="{{.Nonce}}"{{end}}> </script>
+  {{- end}}
+  {{- with .Head}}
+  {{.}}
+  {{- end}}
 </head>
 
 <body>
@@ -300,11 +818,20 @@ const indexTempl = `<!-- HTML for static distribution bundle build -->
   </defs>
 </svg>
 
-<div id="swagger-ui"></div>
+{{if .ValidationFailed}}<div class="validation-banner" role="alert">
+  The API definition has {{len .Validation.Issues}} validation issue(s), see <a href="./validation.json">validation.json</a>.
+</div>
+{{end}}{{with .UnavailableServices}}<div class="availability-banner" role="status">
+  Unavailable services: {{range $i, $name := .}}{{if $i}}, {{end}}{{$name}}{{end}}.
+</div>
+{{end}}<div id="swagger-ui"></div>
 
 <script src="./swagger-ui-bundle.js"> </script>
 <script src="./swagger-ui-standalone-preset.js"> </script>
-<script>
+{{- if .ExternalInitializer}}
+<script src="./swagger-initializer.js"> </script>
+{{- else}}
+<script{{if .Nonce}} nonce="{{.Nonce}}"{{end}}>
 window.onload = function() {
   {{- if .BeforeScript}}
   {{.BeforeScript}}
@@ -312,11 +839,24 @@ window.onload = function() {
   // Build a system
   const ui = SwaggerUIBundle({
     url: "{{.URL}}",
+    {{- if .URLs}}
+    urls: {{.URLs}},
+    {{- if .PrimaryName}}
+    "urls.primaryName": "{{.PrimaryName}}",
+    {{- end}}
+    {{- end}}
     deepLinking: {{.DeepLinking}},
     docExpansion: "{{.DocExpansion}}",
     dom_id: "#{{.DomID}}",
     persistAuthorization: {{.PersistAuthorization}},
-    validatorUrl: null,
+    {{- if .OAuth}}
+    {{- if .OAuth.RedirectURL}}
+    oauth2RedirectUrl: "{{.OAuth.RedirectURL}}",
+    {{- else}}
+    oauth2RedirectUrl: new URL("oauth2-redirect.html", window.location.href).href,
+    {{- end}}
+    {{- end}}
+    validatorUrl: {{if .ValidatorURL}}"{{.ValidatorURL}}"{{else}}null{{end}},
     presets: [
       SwaggerUIBundle.presets.apis,
       SwaggerUIStandalonePreset
@@ -327,6 +867,9 @@ window.onload = function() {
       {{$plugin}}
       {{- end}}
     ],
+    {{- range $setting := .UISettings}}
+    {{$setting.Key}}: {{$setting.Value}},
+    {{- end}}
     {{- range $k, $v := .UIConfig}}
     {{$k}}: {{$v}},
     {{- end}}
@@ -336,11 +879,15 @@ window.onload = function() {
   })
 
   window.ui = ui
+  {{- if .OAuth}}
+  ui.initOAuth({{.OAuth}})
+  {{- end}}
   {{- if .AfterScript}}
   {{.AfterScript}}
   {{- end}}
 }
 </script>
+{{- end}}
 </body>
 
 </html>
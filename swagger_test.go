@@ -104,6 +104,13 @@ func TestWrapHandler(t *testing.T) {
 
 		assert.Equal(t, 301, performRequest(http.MethodGet, test.RootFolder, router).Code)
 
+		for _, name := range []string{"index.html", "doc.json", "swagger-ui.css"} {
+			w := performRequest(http.MethodHead, test.RootFolder+name, router)
+			assert.Equal(t, http.StatusOK, w.Code, name)
+			assert.NotEmpty(t, w.Header().Get("Content-Type"), name)
+			assert.Empty(t, w.Body.String(), name)
+		}
+
 		assert.Equal(t, http.StatusMethodNotAllowed, performRequest(http.MethodPost, test.RootFolder+"index.html", router).Code)
 
 		assert.Equal(t, http.StatusMethodNotAllowed, performRequest(http.MethodPut, test.RootFolder+"index.html", router).Code)
@@ -491,7 +498,7 @@ func TestUIConfigOptions(t *testing.T) {
 			}
 
 			buf := bytes.NewBuffer(nil)
-			if err := index.Execute(buf, fix.cfg); err != nil {
+			if err := index.Execute(buf, IndexData{Config: fix.cfg}); err != nil {
 				t.Fatal(err)
 			}
 
@@ -576,3 +583,51 @@ func TestShowExtensions(t *testing.T) {
 	cfg = newConfig(ShowExtensions(false))
 	assert.False(t, cfg.ShowExtensions)
 }
+
+func TestURLs(t *testing.T) {
+	swag.Register("users", &mockedSwag{})
+	swag.Register("orders", &mockedSwag{})
+
+	router := http.NewServeMux()
+	router.Handle("/swagger/", Handler(
+		URLs(
+			SpecURL{Name: "Users", InstanceName: "users"},
+			SpecURL{Name: "Orders", InstanceName: "orders"},
+			SpecURL{Name: "Remote", URL: "https://example.org/doc.json"},
+		),
+		PrimaryName("Orders"),
+	))
+
+	w1 := performRequest(http.MethodGet, "/swagger/index.html", router)
+	assert.Equal(t, http.StatusOK, w1.Code)
+	assert.Contains(t, w1.Body.String(), `urls: [{"name":"Users","url":"users.json"},{"name":"Orders","url":"orders.json"},{"name":"Remote","url":"https://example.org/doc.json"}],`)
+	assert.Contains(t, w1.Body.String(), `"urls.primaryName": "Orders",`)
+
+	w2 := performRequest(http.MethodGet, "/swagger/index.html?urls.primaryName=Users", router)
+	assert.Equal(t, http.StatusOK, w2.Code)
+	assert.Contains(t, w2.Body.String(), `"urls.primaryName": "Users",`)
+
+	w3 := performRequest(http.MethodGet, "/swagger/index.html?urls.primaryName=Unknown", router)
+	assert.Equal(t, http.StatusOK, w3.Code)
+	assert.Contains(t, w3.Body.String(), `"urls.primaryName": "Orders",`)
+
+	w4 := performRequest(http.MethodGet, "/swagger/users.json", router)
+	assert.Equal(t, http.StatusOK, w4.Code)
+	assert.Equal(t, "application/json; charset=utf-8", w4.Header().Get("Content-Type"))
+	assert.Equal(t, (&mockedSwag{}).ReadDoc(), w4.Body.String())
+
+	assert.Equal(t, http.StatusOK, performRequest(http.MethodGet, "/swagger/orders.json", router).Code)
+	assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/swagger/payments.json", router).Code)
+}
+
+func TestPrimaryName(t *testing.T) {
+	var cfg *Config
+
+	cfg = newConfig()
+	assert.Equal(t, "", cfg.PrimaryName)
+	assert.Empty(t, cfg.URLs)
+
+	cfg = newConfig(URLs(SpecURL{Name: "Users", InstanceName: "users"}), PrimaryName("Users"))
+	assert.Equal(t, "Users", cfg.PrimaryName)
+	assert.Equal(t, []SpecURL{{Name: "Users", URL: "users.json", InstanceName: "users"}}, cfg.URLs)
+}
@@ -0,0 +1,136 @@
+package httpSwagger
+
+import (
+	"encoding/json"
+	"html/template"
+	"io"
+	"io/fs"
+)
+
+// IndexData is the data the index template is executed with. Templates set with
+// IndexTemplate can use all of its fields and methods, along with the functions
+// of IndexFuncs.
+type IndexData struct {
+	*Config
+	// Nonce is the Content-Security-Policy nonce of the inline scripts and styles.
+	// It is empty unless StrictCSP is enabled.
+	Nonce string
+	// Validation is the result of the validation of the documents, nil unless enabled.
+	Validation *ValidationReport
+	// Spec is the document rendered by the ReDoc, RapiDoc and Elements renderers when
+	// embedded in the page by Export, empty when fetched from SpecURL.
+	Spec template.JS
+}
+
+// Head returns HeadHTML, with Nonce added to its script, style and link elements.
+func (d IndexData) Head() template.HTML {
+	return addNonce(d.HeadHTML, d.Nonce)
+}
+
+// UISettings returns the typed Swagger UI settings which differ from the Swagger UI defaults.
+func (d IndexData) UISettings() []UISetting {
+	return d.uiSettings()
+}
+
+// SwaggerConfig returns the JSON encoding of the Swagger UI configuration, as served at
+// `swagger-config.json`. UIConfig values which are not plain JSON are left out.
+func (d IndexData) SwaggerConfig() (template.JS, error) {
+	b, err := d.swaggerConfig().MarshalJSON()
+
+	return template.JS(b), err
+}
+
+// IndexFuncs returns the functions available to index templates:
+//
+//	json     encodes a value as JSON, for use in scripts
+//	safeHTML marks a string as trusted HTML
+//	safeCSS  marks a string as trusted CSS
+//	safeJS   marks a string as trusted JavaScript
+//	safeURL  marks a string as a trusted URL
+func IndexFuncs() template.FuncMap {
+	return template.FuncMap{
+		"json": func(v interface{}) (template.JS, error) {
+			b, err := json.Marshal(v)
+
+			return template.JS(b), err
+		},
+		"safeHTML": func(s string) template.HTML { return template.HTML(s) },
+		"safeCSS":  func(s string) template.CSS { return template.CSS(s) },
+		"safeJS":   func(s string) template.JS { return template.JS(s) },
+		"safeURL":  func(s string) template.URL { return template.URL(s) },
+	}
+}
+
+// Title sets the title of the index page. Defaults to `Swagger UI`.
+func Title(title string) func(*Config) {
+	return func(c *Config) {
+		c.Title = title
+	}
+}
+
+// Favicon sets the URLs of the 32x32 and 16x16 icons of the index page.
+func Favicon(icon32, icon16 string) func(*Config) {
+	return func(c *Config) {
+		c.Favicon32 = icon32
+		c.Favicon16 = icon16
+	}
+}
+
+// Stylesheets adds stylesheets to the index page, after the Swagger UI one.
+// Relative URLs are resolved against the index page, see Files.
+func Stylesheets(urls ...string) func(*Config) {
+	return func(c *Config) {
+		c.Stylesheets = append(c.Stylesheets, urls...)
+	}
+}
+
+// CustomCSS adds CSS rules to the inline style of the index page.
+func CustomCSS(css string) func(*Config) {
+	return func(c *Config) {
+		c.CustomCSS = template.CSS(css)
+	}
+}
+
+// HeadHTML adds content at the end of the `<head>` element of the index page.
+// It must never hold untrusted input.
+func HeadHTML(html string) func(*Config) {
+	return func(c *Config) {
+		c.HeadHTML = template.HTML(html)
+	}
+}
+
+// Files serves the files of fsys next to the index page, taking precedence over the
+// embedded Swagger UI assets, e.g. stylesheets or favicons. Files are read once.
+func Files(fsys fs.FS) func(*Config) {
+	return func(c *Config) {
+		c.Files = fsys
+	}
+}
+
+// IndexTemplate replaces the index page with an html/template executed with IndexData.
+// It is parsed and executed once when the handler is created, to report errors early.
+func IndexTemplate(tmpl string) func(*Config) {
+	return func(c *Config) {
+		c.IndexTemplate = tmpl
+	}
+}
+
+// parseIndex parses the index template and checks that it executes with config.
+func parseIndex(config *Config) (*template.Template, error) {
+	text := rendererTemplate(config.Renderer)
+	if config.IndexTemplate != "" {
+		text = config.IndexTemplate
+	}
+
+	// create a template with name
+	index, err := template.New("swagger_index.html").Funcs(IndexFuncs()).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := index.Execute(io.Discard, IndexData{Config: config}); err != nil {
+		return nil, err
+	}
+
+	return index, nil
+}
@@ -0,0 +1,90 @@
+package httpSwagger
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThemingOptions(t *testing.T) {
+	router := http.NewServeMux()
+	router.Handle("/swagger/", Handler(
+		Title("Petstore API"),
+		Favicon("./logo-32.png", "./logo-16.png"),
+		Stylesheets("./company.css", "https://cdn.example.org/fonts.css"),
+		CustomCSS(".topbar { display: none; }"),
+		HeadHTML(`<meta name="robots" content="noindex">`),
+		Files(fstest.MapFS{
+			"company.css":       {Data: []byte("body { font-family: serif; }")},
+			"favicon-32x32.png": {Data: []byte("png")},
+		}),
+	))
+
+	w := performRequest(http.MethodGet, "/swagger/index.html", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	body := w.Body.String()
+	assert.Contains(t, body, "<title>Petstore API</title>\n")
+	assert.Contains(t, body, "href=\"./swagger-ui.css\" >\n"+
+		"  <link rel=\"stylesheet\" type=\"text/css\" href=\"./company.css\" >\n"+
+		"  <link rel=\"stylesheet\" type=\"text/css\" href=\"https://cdn.example.org/fonts.css\" >\n"+
+		"  <link rel=\"icon\" type=\"image/png\" href=\"./logo-32.png\" sizes=\"32x32\" />\n"+
+		"  <link rel=\"icon\" type=\"image/png\" href=\"./logo-16.png\" sizes=\"16x16\" />\n")
+	assert.Contains(t, body, "      background: #fafafa;\n    }\n    .topbar { display: none; }\n  </style>\n"+
+		"  <meta name=\"robots\" content=\"noindex\">\n</head>\n")
+
+	w = performRequest(http.MethodGet, "/swagger/company.css", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/css; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "body { font-family: serif; }", w.Body.String())
+
+	w = performRequest(http.MethodGet, "/swagger/favicon-32x32.png", router)
+	assert.Equal(t, "png", w.Body.String())
+
+	w = performRequest(http.MethodGet, "/swagger/favicon-16x16.png", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, "png", w.Body.String())
+}
+
+func TestIndexTemplate(t *testing.T) {
+	router := http.NewServeMux()
+	router.Handle("/swagger/", Handler(
+		Title("Petstore <API>"),
+		StrictCSP(true),
+		TryItOutEnabled(true),
+		IndexTemplate(`<title>{{.Title}}</title>`+
+			`<script nonce="{{.Nonce}}">const config = {{.SwaggerConfig}}; const urls = {{json .URLs}};</script>`+
+			`{{range .UISettings}}<i>{{.Key}}</i>{{end}}{{safeHTML "<hr>"}}`),
+	))
+
+	w := performRequest(http.MethodGet, "/swagger/index.html", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	body := w.Body.String()
+	assert.True(t, strings.HasPrefix(body, "<title>Petstore &lt;API&gt;</title><script nonce=\""), body)
+	assert.Contains(t, body, `const config = {"url":"doc.json","deepLinking":true,`)
+	assert.Contains(t, body, `"tryItOutEnabled":true`)
+	assert.Contains(t, body, `const urls = [];</script><i>tryItOutEnabled</i><hr>`)
+}
+
+func TestIndexTemplateErrors(t *testing.T) {
+	_, err := NewHandler(IndexTemplate(`{{.Title`))
+	assert.EqualError(t, err, `invalid configuration: index template: template: swagger_index.html:1: unclosed action`)
+
+	_, err = NewHandler(IndexTemplate(`{{.Unknown}}`))
+	assert.EqualError(t, err, `invalid configuration: index template: template: swagger_index.html:1:2: `+
+		`executing "swagger_index.html" at <.Unknown>: can't evaluate field Unknown in type httpSwagger.IndexData`)
+
+	_, err = NewHandler(Favicon("%zz", ""), Stylesheets("%zz"))
+	assert.EqualError(t, err, `invalid configuration: favicon: parse "%zz": invalid URL escape "%zz"; `+
+		`stylesheet: parse "%zz": invalid URL escape "%zz"`)
+
+	router := http.NewServeMux()
+	router.Handle("/swagger/", Handler(IndexTemplate(`{{template "missing"}}`)))
+
+	w := performRequest(http.MethodGet, "/swagger/index.html", router)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,38 @@
+package httpSwagger
+
+import "fmt"
+
+// ThemeMode is the color theme of the index page.
+type ThemeMode string
+
+const (
+	ThemeLight  ThemeMode = "light"
+	ThemeDark   ThemeMode = "dark"
+	ThemeSystem ThemeMode = "system"
+)
+
+func (m ThemeMode) valid() bool {
+	return m == ThemeLight || m == ThemeDark || m == ThemeSystem
+}
+
+// Theme sets the color theme of the index page. ThemeSystem follows the
+// `prefers-color-scheme` setting of the browser.
+func Theme(mode ThemeMode) func(*Config) {
+	return func(c *Config) {
+		if !mode.valid() {
+			c.errs = append(c.errs, fmt.Errorf("unsupported theme %q", mode))
+
+			return
+		}
+
+		c.Theme = mode
+	}
+}
+
+// ThemeSwitcher renders a button switching between the light and dark themes.
+// The choice is remembered in the localStorage of the browser.
+func ThemeSwitcher(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.ThemeSwitcher = enabled
+	}
+}
@@ -0,0 +1,46 @@
+package httpSwagger
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTheme(t *testing.T) {
+	router := http.NewServeMux()
+	router.Handle("/dark/", Handler(Theme(ThemeDark)))
+	router.Handle("/system/", Handler(Theme(ThemeSystem), ThemeSwitcher(true)))
+	router.Handle("/switcher/", Handler(ThemeSwitcher(true)))
+	router.Handle("/light/", Handler())
+
+	w := performRequest(http.MethodGet, "/dark/index.html", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `<html lang="en" data-theme-mode="dark">`)
+	assert.Contains(t, w.Body.String(), "\n  <link rel=\"stylesheet\" type=\"text/css\" href=\"./swagger-ui-dark.css\" >\n"+
+		"  <script src=\"./swagger-ui-theme.js\"> </script>\n</head>")
+
+	w = performRequest(http.MethodGet, "/system/index.html", router)
+	assert.Contains(t, w.Body.String(), `<html lang="en" data-theme-mode="system">`)
+	assert.Contains(t, w.Body.String(), `<script src="./swagger-ui-theme.js" data-switcher> </script>`)
+
+	w = performRequest(http.MethodGet, "/switcher/index.html", router)
+	assert.Contains(t, w.Body.String(), "<html lang=\"en\">\n")
+	assert.Contains(t, w.Body.String(), `<script src="./swagger-ui-theme.js" data-switcher> </script>`)
+
+	w = performRequest(http.MethodGet, "/light/index.html", router)
+	assert.NotContains(t, w.Body.String(), "swagger-ui-dark.css")
+
+	w = performRequest(http.MethodGet, "/light/swagger-ui-dark.css", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/css; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `html[data-theme="dark"] body {`)
+
+	w = performRequest(http.MethodGet, "/light/swagger-ui-theme.js", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/javascript", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `"swagger-ui-theme"`)
+
+	_, err := NewHandler(Theme("sepia"))
+	assert.EqualError(t, err, `invalid configuration: unsupported theme "sepia"`)
+}
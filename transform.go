@@ -0,0 +1,416 @@
+package httpSwagger
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Document is a parsed API definition, either Swagger 2.0 or OpenAPI 3. Objects are
+// held as map[string]interface{}, arrays as []interface{} and numbers as json.Number.
+type Document map[string]interface{}
+
+// DocTransformer modifies a document before it is served.
+type DocTransformer func(doc Document) error
+
+// Transform applies transformers in order to the served documents. The results are
+// cached until the documents change. Transformed documents have their keys sorted.
+func Transform(transformers ...DocTransformer) func(*Config) {
+	return func(c *Config) {
+		c.Transformers = append(c.Transformers, transformers...)
+	}
+}
+
+// RemoveInternal removes the operations marked with `x-internal: true`, along with
+// the paths left without operations and the definitions only they referenced.
+func RemoveInternal() DocTransformer {
+	return func(doc Document) error {
+		doc.removeOperations(func(op map[string]interface{}) bool {
+			internal, _ := op["x-internal"].(bool)
+
+			return internal
+		})
+
+		return nil
+	}
+}
+
+// FilterTags keeps the operations having at least one of tags, and the matching
+// tag descriptions. The definitions only the other operations referenced are removed.
+func FilterTags(tags ...string) DocTransformer {
+	keep := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		keep[tag] = true
+	}
+
+	return func(doc Document) error {
+		doc.removeOperations(func(op map[string]interface{}) bool {
+			opTags, _ := op["tags"].([]interface{})
+			for _, tag := range opTags {
+				if name, _ := tag.(string); keep[name] {
+					return false
+				}
+			}
+
+			return true
+		})
+
+		if list, ok := doc["tags"].([]interface{}); ok {
+			filtered := make([]interface{}, 0, len(list))
+			for _, tag := range list {
+				t, _ := tag.(map[string]interface{})
+				if name, _ := t["name"].(string); keep[name] {
+					filtered = append(filtered, tag)
+				}
+			}
+
+			doc["tags"] = filtered
+		}
+
+		return nil
+	}
+}
+
+// StripExtensions removes the vendor extensions whose name starts with one of
+// prefixes, e.g. `x-internal-`, or all of them when no prefix is given.
+func StripExtensions(prefixes ...string) DocTransformer {
+	strip := func(key string) bool {
+		if !isExtension(key) {
+			return false
+		}
+
+		if len(prefixes) == 0 {
+			return true
+		}
+
+		for _, p := range prefixes {
+			if strings.HasPrefix(key, p) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for k, child := range v {
+				if strip(k) {
+					delete(v, k)
+
+					continue
+				}
+
+				walk(child)
+			}
+		case []interface{}:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+
+	return func(doc Document) error {
+		walk(map[string]interface{}(doc))
+
+		return nil
+	}
+}
+
+// InfoOverride holds the document info fields replaced by OverrideInfo.
+// Empty fields are left unchanged.
+type InfoOverride struct {
+	Title          string
+	Description    string
+	Version        string
+	TermsOfService string
+}
+
+// OverrideInfo replaces fields of the document info.
+func OverrideInfo(override InfoOverride) DocTransformer {
+	return func(doc Document) error {
+		info, _ := doc["info"].(map[string]interface{})
+		if info == nil {
+			info = make(map[string]interface{})
+			doc["info"] = info
+		}
+
+		for key, value := range map[string]string{
+			"title":          override.Title,
+			"description":    override.Description,
+			"version":        override.Version,
+			"termsOfService": override.TermsOfService,
+		} {
+			if value != "" {
+				info[key] = value
+			}
+		}
+
+		return nil
+	}
+}
+
+// AddSecurity adds security schemes to the document, as `securityDefinitions` in
+// Swagger 2.0 and `components.securitySchemes` in OpenAPI 3, and appends requirements
+// to its global security requirements.
+func AddSecurity(schemes map[string]interface{}, requirements ...map[string][]string) DocTransformer {
+	return func(doc Document) error {
+		var defs map[string]interface{}
+		if _, ok := doc["openapi"]; ok {
+			defs = Document(doc.object("components")).object("securitySchemes")
+		} else {
+			defs = doc.object("securityDefinitions")
+		}
+
+		for name, scheme := range schemes {
+			defs[name] = scheme
+		}
+
+		security, _ := doc["security"].([]interface{})
+		for _, req := range requirements {
+			security = append(security, req)
+		}
+
+		if security != nil {
+			doc["security"] = security
+		}
+
+		return nil
+	}
+}
+
+// object returns the object held by key, adding an empty one when missing.
+func (doc Document) object(key string) map[string]interface{} {
+	o, ok := doc[key].(map[string]interface{})
+	if !ok {
+		o = make(map[string]interface{})
+		doc[key] = o
+	}
+
+	return o
+}
+
+// removeOperations removes the operations matched by remove, along with the
+// paths left without operations and the reusable objects only they referenced.
+func (doc Document) removeOperations(remove func(op map[string]interface{}) bool) {
+	paths, _ := doc["paths"].(map[string]interface{})
+	referenced := doc.referenced()
+	removedAny := false
+
+	for path, item := range paths {
+		pathItem, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		removed := false
+		for method, op := range pathItem {
+			if o, ok := op.(map[string]interface{}); ok && isOperationMethod(method) && remove(o) {
+				delete(pathItem, method)
+
+				removed = true
+			}
+		}
+
+		if removed && !hasOperations(pathItem) {
+			delete(paths, path)
+		}
+
+		removedAny = removedAny || removed
+	}
+
+	if !removedAny {
+		return
+	}
+
+	// objects unreferenced from the start are left, they may be published on purpose
+	still := doc.referenced()
+
+	for path, names := range referenced {
+		collection := doc.collection(path)
+
+		for name := range names {
+			if !still[path][name] {
+				delete(collection, name)
+			}
+		}
+	}
+}
+
+// referenced returns the names of the reusable objects referenced from the rest of
+// the document, directly or through other objects, by collection path.
+func (doc Document) referenced() map[string]map[string]bool {
+	oas3 := doc["openapi"] != nil
+	if _, ok := doc["components"].(map[string]interface{}); oas3 && !ok {
+		return nil
+	}
+
+	collections := make(map[string]bool)
+	for path, byRef := range doc.collections(oas3) {
+		if byRef {
+			collections[path] = true
+		}
+	}
+
+	referenced := make(map[string]map[string]bool)
+
+	var walk func(value interface{})
+	walk = func(value interface{}) {
+		switch value := value.(type) {
+		case map[string]interface{}:
+			if ref, ok := value["$ref"].(string); ok {
+				for collection := range collections {
+					name, ok := refName(ref, collection)
+					if !ok || referenced[collection][name] {
+						continue
+					}
+
+					if referenced[collection] == nil {
+						referenced[collection] = make(map[string]bool)
+					}
+
+					referenced[collection][name] = true
+
+					walk(doc.collection(collection)[name])
+				}
+			}
+
+			for _, child := range value {
+				walk(child)
+			}
+		case []interface{}:
+			for _, child := range value {
+				walk(child)
+			}
+		}
+	}
+
+	// the reusable objects are only walked when referenced
+	for k, v := range doc {
+		switch {
+		case collections[k]:
+		case oas3 && k == "components":
+			components, _ := v.(map[string]interface{})
+
+			for name, component := range components {
+				if !collections["components/"+name] {
+					walk(component)
+				}
+			}
+		default:
+			walk(v)
+		}
+	}
+
+	return referenced
+}
+
+// refName returns the name of the object of collection the local reference ref
+// points to or into.
+func refName(ref, collection string) (string, bool) {
+	prefix := "#/" + collection + "/"
+	if !strings.HasPrefix(ref, prefix) {
+		return "", false
+	}
+
+	name := strings.SplitN(ref[len(prefix):], "/", 2)[0]
+
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~1", "/"), "~0", "~"), true
+}
+
+func hasOperations(pathItem map[string]interface{}) bool {
+	for method := range pathItem {
+		if isOperationMethod(method) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isOperationMethod reports whether the path item key method holds an operation.
+func isOperationMethod(method string) bool {
+	// trace operations only exist in OpenAPI 3
+	if method == "trace" {
+		return true
+	}
+
+	for _, m := range operationMethods {
+		if m == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+// transformDoc applies transformers to the documents read by read.
+func transformDoc(read docReader, transformers []DocTransformer) docReader {
+	if len(transformers) == 0 {
+		return read
+	}
+
+	var (
+		mu     sync.Mutex
+		source [sha256.Size]byte
+		result []byte
+	)
+
+	return func(r *http.Request) ([]byte, error) {
+		doc, err := read(r)
+		if err != nil {
+			return nil, err
+		}
+
+		sum := sha256.Sum256(doc)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if result != nil && sum == source {
+			return result, nil
+		}
+
+		out, err := applyTransformers(doc, transformers)
+		if err != nil {
+			return nil, err
+		}
+
+		source, result = sum, out
+
+		return out, nil
+	}
+}
+
+// applyTransformers parses the JSON document doc and applies transformers to it.
+func applyTransformers(doc []byte, transformers []DocTransformer) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+
+	var parsed Document
+	if err := dec.Decode(&parsed); err != nil {
+		return nil, err
+	}
+
+	for _, t := range transformers {
+		if err := t(parsed); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	if err := enc.Encode(parsed); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,181 @@
+package httpSwagger
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const transformDocument = `{
+  "swagger": "2.0",
+  "info": {"title": "Petstore", "version": "1.0", "x-logo": "logo.png"},
+  "tags": [{"name": "pets"}, {"name": "admin"}],
+  "paths": {
+    "/pets": {
+      "get": {"tags": ["pets"], "x-rate-limit": 100, "responses": {"200": {"description": "<ok>"}}},
+      "post": {"tags": ["pets"], "x-internal": true, "responses": {"201": {"description": "created"}}}
+    },
+    "/admin": {
+      "parameters": [],
+      "delete": {"tags": ["admin"], "x-internal": true, "responses": {"204": {"description": "deleted"}}}
+    },
+    "/users": {
+      "get": {"tags": ["admin"], "responses": {"200": {"description": "ok"}}}
+    }
+  }
+}`
+
+func TestTransformers(t *testing.T) {
+	tests := []struct {
+		desc         string
+		transformers []DocTransformer
+		exp          string
+	}{
+		{
+			desc:         "remove internal",
+			transformers: []DocTransformer{RemoveInternal(), StripExtensions()},
+			exp: `{"info":{"title":"Petstore","version":"1.0"},"paths":{` +
+				`"/pets":{"get":{"responses":{"200":{"description":"<ok>"}},"tags":["pets"]}},` +
+				`"/users":{"get":{"responses":{"200":{"description":"ok"}},"tags":["admin"]}}},` +
+				`"swagger":"2.0","tags":[{"name":"pets"},{"name":"admin"}]}`,
+		},
+		{
+			desc:         "filter tags",
+			transformers: []DocTransformer{FilterTags("pets"), StripExtensions("x-rate-", "x-internal")},
+			exp: `{"info":{"title":"Petstore","version":"1.0","x-logo":"logo.png"},"paths":{"/pets":{` +
+				`"get":{"responses":{"200":{"description":"<ok>"}},"tags":["pets"]},` +
+				`"post":{"responses":{"201":{"description":"created"}},"tags":["pets"]}}},` +
+				`"swagger":"2.0","tags":[{"name":"pets"}]}`,
+		},
+		{
+			desc: "override info and add security",
+			transformers: []DocTransformer{
+				FilterTags(),
+				OverrideInfo(InfoOverride{Title: "Public API", Version: "2.0.1"}),
+				AddSecurity(map[string]interface{}{
+					"apiKey": map[string]string{"type": "apiKey", "name": "X-API-Key", "in": "header"},
+				}, map[string][]string{"apiKey": {}}),
+			},
+			exp: `{"info":{"title":"Public API","version":"2.0.1","x-logo":"logo.png"},"paths":{},` +
+				`"security":[{"apiKey":[]}],"securityDefinitions":{"apiKey":{"in":"header","name":"X-API-Key","type":"apiKey"}},` +
+				`"swagger":"2.0","tags":[]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			out, err := applyTransformers([]byte(transformDocument), test.transformers)
+			assert.NoError(t, err)
+			assert.Equal(t, test.exp, string(out))
+		})
+	}
+}
+
+func TestRemoveUnreferencedDefinitions(t *testing.T) {
+	out, err := applyTransformers([]byte(`{
+		"swagger": "2.0",
+		"paths": {
+			"/pets": {"get": {"responses": {"200": {"schema": {"$ref": "#/definitions/Pet"}}}}},
+			"/admin": {
+				"parameters": [{"$ref": "#/parameters/Tenant"}],
+				"delete": {"x-internal": true, "responses": {"200": {"schema": {"$ref": "#/definitions/Secret"}}}}
+			}
+		},
+		"definitions": {
+			"Pet": {"properties": {"owner": {"$ref": "#/definitions/Owner"}}},
+			"Owner": {"type": "object"},
+			"Secret": {"properties": {"owner": {"$ref": "#/definitions/Owner"}, "audit": {"$ref": "#/definitions/Audit/properties/at"}}},
+			"Audit": {"properties": {"at": {"type": "string"}}},
+			"Unused": {"type": "object"}
+		},
+		"parameters": {"Tenant": {"name": "tenant", "in": "query", "type": "string"}}
+	}`), []DocTransformer{RemoveInternal()})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"swagger": "2.0",
+		"paths": {"/pets": {"get": {"responses": {"200": {"schema": {"$ref": "#/definitions/Pet"}}}}}},
+		"definitions": {
+			"Pet": {"properties": {"owner": {"$ref": "#/definitions/Owner"}}},
+			"Owner": {"type": "object"},
+			"Unused": {"type": "object"}
+		},
+		"parameters": {}
+	}`, string(out))
+
+	out, err = applyTransformers([]byte(`{
+		"openapi": "3.0.3",
+		"paths": {
+			"/pets": {"get": {"tags": ["pets"], "responses": {"200": {"$ref": "#/components/responses/Pets"}}}},
+			"/admin": {"get": {"tags": ["admin"], "responses": {"200": {"$ref": "#/components/responses/Admin"}}}}
+		},
+		"components": {
+			"responses": {
+				"Pets": {"description": "pets", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/pets~1Pet"}}}},
+				"Admin": {"description": "admin", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Secret"}}}}
+			},
+			"schemas": {"pets/Pet": {"type": "object"}, "Secret": {"type": "object"}},
+			"securitySchemes": {"key": {"type": "apiKey", "name": "X-Key", "in": "header"}}
+		}
+	}`), []DocTransformer{FilterTags("pets")})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"openapi": "3.0.3",
+		"paths": {"/pets": {"get": {"tags": ["pets"], "responses": {"200": {"$ref": "#/components/responses/Pets"}}}}},
+		"components": {
+			"responses": {"Pets": {"description": "pets", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/pets~1Pet"}}}}},
+			"schemas": {"pets/Pet": {"type": "object"}},
+			"securitySchemes": {"key": {"type": "apiKey", "name": "X-Key", "in": "header"}}
+		}
+	}`, string(out))
+}
+
+func TestAddSecurityOpenAPI3(t *testing.T) {
+	doc := Document{"openapi": "3.0.3", "components": map[string]interface{}{}}
+	assert.NoError(t, AddSecurity(map[string]interface{}{"bearer": map[string]string{"type": "http"}})(doc))
+	assert.Equal(t, Document{
+		"openapi": "3.0.3",
+		"components": map[string]interface{}{
+			"securitySchemes": map[string]interface{}{"bearer": map[string]string{"type": "http"}},
+		},
+	}, doc)
+}
+
+func TestTransformCache(t *testing.T) {
+	doc := `{"swagger":"2.0","info":{"version":"1"}}`
+	calls := 0
+
+	router := http.NewServeMux()
+	router.Handle("/swagger/", Handler(
+		Provider(DocProviderFunc(func(ctx context.Context) ([]byte, string, error) {
+			return []byte(doc), "application/json", nil
+		})),
+		Transform(func(d Document) error {
+			calls++
+
+			return nil
+		}, OverrideInfo(InfoOverride{Title: "API"})),
+	))
+	router.Handle("/failing/", Handler(Transform(func(Document) error {
+		return errors.New("failed")
+	})))
+
+	for i := 0; i < 2; i++ {
+		w := performRequest(http.MethodGet, "/swagger/doc.json", router)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `{"info":{"title":"API","version":"1"},"swagger":"2.0"}`, w.Body.String())
+	}
+
+	assert.Equal(t, 1, calls)
+
+	doc = `{"swagger":"2.0","info":{"version":"2"}}`
+
+	w := performRequest(http.MethodGet, "/swagger/doc.yaml", router)
+	assert.Equal(t, "info:\n  title: API\n  version: \"2\"\nswagger: \"2.0\"\n", w.Body.String())
+	assert.Equal(t, 2, calls)
+
+	w = performRequest(http.MethodGet, "/failing/doc.json", router)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,340 @@
+package httpSwagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/url"
+)
+
+// SubmitMethod is an HTTP method "Try it out" is enabled for.
+type SubmitMethod string
+
+const (
+	SubmitGet     SubmitMethod = "get"
+	SubmitPut     SubmitMethod = "put"
+	SubmitPost    SubmitMethod = "post"
+	SubmitDelete  SubmitMethod = "delete"
+	SubmitOptions SubmitMethod = "options"
+	SubmitHead    SubmitMethod = "head"
+	SubmitPatch   SubmitMethod = "patch"
+	SubmitTrace   SubmitMethod = "trace"
+)
+
+func (m SubmitMethod) valid() bool {
+	switch m {
+	case SubmitGet, SubmitPut, SubmitPost, SubmitDelete, SubmitOptions, SubmitHead, SubmitPatch, SubmitTrace:
+		return true
+	}
+
+	return false
+}
+
+// ModelRendering controls how models are shown when the API is first rendered.
+type ModelRendering string
+
+const (
+	RenderExample ModelRendering = "example"
+	RenderModel   ModelRendering = "model"
+)
+
+func (r ModelRendering) valid() bool {
+	return r == RenderExample || r == RenderModel
+}
+
+// OperationsSorterType sorts the operations of each API.
+type OperationsSorterType string
+
+const (
+	AlphaOperationsSorter  OperationsSorterType = "alpha"
+	MethodOperationsSorter OperationsSorterType = "method"
+)
+
+func (s OperationsSorterType) valid() bool {
+	return s == AlphaOperationsSorter || s == MethodOperationsSorter
+}
+
+// TagsSorterType sorts the tags.
+type TagsSorterType string
+
+const (
+	AlphaTagsSorter TagsSorterType = "alpha"
+)
+
+func (s TagsSorterType) valid() bool {
+	return s == AlphaTagsSorter
+}
+
+// SyntaxTheme is a highlight.js theme used for syntax highlighting.
+type SyntaxTheme string
+
+const (
+	AgateTheme         SyntaxTheme = "agate"
+	ArtaTheme          SyntaxTheme = "arta"
+	MonokaiTheme       SyntaxTheme = "monokai"
+	NordTheme          SyntaxTheme = "nord"
+	ObsidianTheme      SyntaxTheme = "obsidian"
+	TomorrowNightTheme SyntaxTheme = "tomorrow-night"
+	IdeaTheme          SyntaxTheme = "idea"
+)
+
+func (t SyntaxTheme) valid() bool {
+	switch t {
+	case AgateTheme, ArtaTheme, MonokaiTheme, NordTheme, ObsidianTheme, TomorrowNightTheme, IdeaTheme:
+		return true
+	}
+
+	return false
+}
+
+// SyntaxHighlightConfig holds the syntax highlighting settings of request and response bodies.
+type SyntaxHighlightConfig struct {
+	Activated bool        `json:"activated"`
+	Theme     SyntaxTheme `json:"theme,omitempty"`
+}
+
+// Filter enables filtering the operations by tag.
+func Filter(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.Filter = enabled
+	}
+}
+
+// FilterExpression enables filtering the operations by tag, pre-filled with expression.
+func FilterExpression(expression string) func(*Config) {
+	return func(c *Config) {
+		c.Filter = true
+		c.FilterExpression = expression
+	}
+}
+
+// TryItOutEnabled enables "Try it out" for all operations by default.
+func TryItOutEnabled(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.TryItOutEnabled = enabled
+	}
+}
+
+// SupportedSubmitMethods lists the HTTP methods "Try it out" is enabled for.
+func SupportedSubmitMethods(methods ...SubmitMethod) func(*Config) {
+	return func(c *Config) {
+		c.SupportedSubmitMethods = make([]SubmitMethod, 0, len(methods))
+
+		for _, m := range methods {
+			if !m.valid() {
+				c.errs = append(c.errs, fmt.Errorf("unsupported submit method %q", m))
+
+				continue
+			}
+
+			c.SupportedSubmitMethods = append(c.SupportedSubmitMethods, m)
+		}
+	}
+}
+
+// DisplayRequestDuration displays the duration of "Try it out" requests.
+func DisplayRequestDuration(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.DisplayRequestDuration = enabled
+	}
+}
+
+// DisplayOperationID displays the operationId of operations.
+func DisplayOperationID(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.DisplayOperationID = enabled
+	}
+}
+
+// DefaultModelExpandDepth sets the default expansion depth of the model in the operations.
+// Defaults to 1.
+func DefaultModelExpandDepth(depth int) func(*Config) {
+	return func(c *Config) {
+		c.DefaultModelExpandDepth = &depth
+	}
+}
+
+// DefaultModelRendering controls how models are shown when the API is first rendered.
+// Defaults to RenderExample.
+func DefaultModelRendering(rendering ModelRendering) func(*Config) {
+	return func(c *Config) {
+		if !rendering.valid() {
+			c.errs = append(c.errs, fmt.Errorf("unsupported model rendering %q", rendering))
+
+			return
+		}
+
+		c.DefaultModelRendering = rendering
+	}
+}
+
+// MaxDisplayedTags limits the number of tagged operations displayed. Zero displays all of them.
+func MaxDisplayedTags(n int) func(*Config) {
+	return func(c *Config) {
+		if n < 0 {
+			c.errs = append(c.errs, fmt.Errorf("negative max displayed tags %d", n))
+
+			return
+		}
+
+		c.MaxDisplayedTags = n
+	}
+}
+
+// ShowCommonExtensions displays the extension fields and values of parameters.
+func ShowCommonExtensions(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.ShowCommonExtensions = enabled
+	}
+}
+
+// SyntaxHighlight controls the syntax highlighting of request and response bodies.
+// An empty theme keeps the Swagger UI default.
+func SyntaxHighlight(activated bool, theme SyntaxTheme) func(*Config) {
+	return func(c *Config) {
+		if theme != "" && !theme.valid() {
+			c.errs = append(c.errs, fmt.Errorf("unsupported syntax highlight theme %q", theme))
+
+			return
+		}
+
+		c.SyntaxHighlight = &SyntaxHighlightConfig{Activated: activated, Theme: theme}
+	}
+}
+
+// RequestSnippetsEnabled displays code snippets for "Try it out" requests.
+func RequestSnippetsEnabled(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.RequestSnippetsEnabled = enabled
+	}
+}
+
+// OperationsSorter sorts the operations of each API. By default they are shown in the order of the document.
+func OperationsSorter(sorter OperationsSorterType) func(*Config) {
+	return func(c *Config) {
+		if !sorter.valid() {
+			c.errs = append(c.errs, fmt.Errorf("unsupported operations sorter %q", sorter))
+
+			return
+		}
+
+		c.OperationsSorter = sorter
+	}
+}
+
+// TagsSorter sorts the tags. By default they are shown in the order of the document.
+func TagsSorter(sorter TagsSorterType) func(*Config) {
+	return func(c *Config) {
+		if !sorter.valid() {
+			c.errs = append(c.errs, fmt.Errorf("unsupported tags sorter %q", sorter))
+
+			return
+		}
+
+		c.TagsSorter = sorter
+	}
+}
+
+// ValidatorURL sets the URL of the validator badge. By default no validation is performed.
+func ValidatorURL(validatorURL string) func(*Config) {
+	return func(c *Config) {
+		u, err := url.Parse(validatorURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			c.errs = append(c.errs, fmt.Errorf("invalid validator URL %q", validatorURL))
+
+			return
+		}
+
+		c.ValidatorURL = validatorURL
+	}
+}
+
+// WithCredentials sends credentials with the CORS requests of "Try it out".
+func WithCredentials(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.WithCredentials = enabled
+	}
+}
+
+// UISetting is a Swagger UI setting with its JSON-encoded value.
+type UISetting struct {
+	// Key is the name of the SwaggerUIBundle config object property.
+	Key string
+	// Value is the JSON encoding of the property value.
+	Value template.JS
+}
+
+// uiSettings returns the typed Swagger UI settings that differ from the Swagger UI defaults.
+func (c *Config) uiSettings() []UISetting {
+	var settings []UISetting
+
+	add := func(key string, v interface{}) {
+		b, _ := json.Marshal(v)
+		settings = append(settings, UISetting{Key: key, Value: template.JS(b)})
+	}
+
+	switch {
+	case c.FilterExpression != "":
+		add("filter", c.FilterExpression)
+	case c.Filter:
+		add("filter", true)
+	}
+
+	if c.TryItOutEnabled {
+		add("tryItOutEnabled", true)
+	}
+
+	if c.SupportedSubmitMethods != nil {
+		add("supportedSubmitMethods", c.SupportedSubmitMethods)
+	}
+
+	if c.DisplayRequestDuration {
+		add("displayRequestDuration", true)
+	}
+
+	if c.DisplayOperationID {
+		add("displayOperationId", true)
+	}
+
+	if c.DefaultModelExpandDepth != nil {
+		add("defaultModelExpandDepth", *c.DefaultModelExpandDepth)
+	}
+
+	if c.DefaultModelRendering != "" {
+		add("defaultModelRendering", c.DefaultModelRendering)
+	}
+
+	if c.MaxDisplayedTags > 0 {
+		add("maxDisplayedTags", c.MaxDisplayedTags)
+	}
+
+	if c.ShowCommonExtensions {
+		add("showCommonExtensions", true)
+	}
+
+	if c.SyntaxHighlight != nil {
+		if c.SyntaxHighlight.Activated {
+			add("syntaxHighlight", c.SyntaxHighlight)
+		} else {
+			add("syntaxHighlight", false)
+		}
+	}
+
+	if c.RequestSnippetsEnabled {
+		add("requestSnippetsEnabled", true)
+	}
+
+	if c.OperationsSorter != "" {
+		add("operationsSorter", c.OperationsSorter)
+	}
+
+	if c.TagsSorter != "" {
+		add("tagsSorter", c.TagsSorter)
+	}
+
+	if c.WithCredentials {
+		add("withCredentials", true)
+	}
+
+	return settings
+}
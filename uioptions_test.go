@@ -0,0 +1,77 @@
+package httpSwagger
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUISettings(t *testing.T) {
+	cfg := newConfig(
+		FilterExpression("pets"),
+		TryItOutEnabled(true),
+		SupportedSubmitMethods(SubmitGet, SubmitPost),
+		DisplayRequestDuration(true),
+		DisplayOperationID(true),
+		DefaultModelExpandDepth(0),
+		DefaultModelRendering(RenderModel),
+		MaxDisplayedTags(5),
+		ShowCommonExtensions(true),
+		SyntaxHighlight(true, MonokaiTheme),
+		RequestSnippetsEnabled(true),
+		OperationsSorter(MethodOperationsSorter),
+		TagsSorter(AlphaTagsSorter),
+		ValidatorURL("https://validator.swagger.io/validator"),
+		WithCredentials(true),
+	)
+	assert.NoError(t, cfg.err())
+
+	out, err := cfg.swaggerConfig().MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"url":"doc.json","deepLinking":true,"docExpansion":"list","dom_id":"#swagger-ui","persistAuthorization":false,`+
+		`"validatorUrl":"https://validator.swagger.io/validator","filter":"pets","tryItOutEnabled":true,`+
+		`"supportedSubmitMethods":["get","post"],"displayRequestDuration":true,"displayOperationId":true,`+
+		`"defaultModelExpandDepth":0,"defaultModelRendering":"model","maxDisplayedTags":5,"showCommonExtensions":true,`+
+		`"syntaxHighlight":{"activated":true,"theme":"monokai"},"requestSnippetsEnabled":true,"operationsSorter":"method",`+
+		`"tagsSorter":"alpha","withCredentials":true,"layout":"StandaloneLayout","defaultModelsExpandDepth":1,"showExtensions":false}`, string(out))
+
+	cfg = newConfig(Filter(true), SupportedSubmitMethods(), SyntaxHighlight(false, ""))
+	assert.Equal(t, []UISetting{
+		{Key: "filter", Value: "true"},
+		{Key: "supportedSubmitMethods", Value: "[]"},
+		{Key: "syntaxHighlight", Value: "false"},
+	}, cfg.uiSettings())
+}
+
+func TestUISettingsErrors(t *testing.T) {
+	cfg := newConfig(
+		SupportedSubmitMethods(SubmitGet, "connect"),
+		DefaultModelRendering("schema"),
+		MaxDisplayedTags(-1),
+		SyntaxHighlight(true, "solarized"),
+		OperationsSorter("path"),
+		TagsSorter("length"),
+		ValidatorURL("javascript:alert(1)"),
+	)
+	assert.EqualError(t, cfg.err(), `invalid configuration: unsupported submit method "connect"; `+
+		`unsupported model rendering "schema"; negative max displayed tags -1; `+
+		`unsupported syntax highlight theme "solarized"; unsupported operations sorter "path"; `+
+		`unsupported tags sorter "length"; invalid validator URL "javascript:alert(1)"`)
+	assert.Equal(t, []SubmitMethod{SubmitGet}, cfg.SupportedSubmitMethods)
+	assert.Empty(t, cfg.ValidatorURL)
+}
+
+func TestUISettingsIndex(t *testing.T) {
+	router := http.NewServeMux()
+	router.Handle("/swagger/", Handler(
+		TryItOutEnabled(true),
+		SupportedSubmitMethods(SubmitGet),
+		ValidatorURL("https://validator.swagger.io/validator"),
+	))
+
+	w := performRequest(http.MethodGet, "/swagger/index.html", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "\n    validatorUrl: \"https:\\/\\/validator.swagger.io\\/validator\",\n")
+	assert.Contains(t, w.Body.String(), "\n    \"tryItOutEnabled\": true,\n    \"supportedSubmitMethods\": [\"get\"],\n")
+}
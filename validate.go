@@ -0,0 +1,291 @@
+package httpSwagger
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// integrityPattern matches the Subresource Integrity hashes supported by browsers.
+var integrityPattern = regexp.MustCompile(`^sha(256|384|512)-[A-Za-z0-9+/]+={0,2}$`)
+
+// domIDPattern matches the DOM ids usable as a CSS id selector without escaping.
+var domIDPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// NewHandler returns the Swagger UI handler configured by opts. Unlike Handler, it
+// validates the whole configuration and reports every problem found in a ConfigError.
+func NewHandler(opts ...func(*Config)) (http.Handler, error) {
+	h, err := newValidHandler(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// newValidHandler returns the handler configured by opts, or the problems of its configuration.
+func newValidHandler(opts ...func(*Config)) (*handler, error) {
+	config := newConfig(opts...)
+
+	// the documents are only read, validated and compressed for valid configurations
+	if err := newConfigError(append(config.errs, config.validate()...)); err != nil {
+		return nil, err
+	}
+
+	h := newHandler(config)
+	if h.err != nil {
+		return nil, h.err
+	}
+
+	return h, nil
+}
+
+// validate returns the problems of the configuration which Handler tolerates
+// for compatibility.
+func (c *Config) validate() []error {
+	var errs []error
+
+	switch c.DocExpansion {
+	case "list", "full", "none":
+	default:
+		errs = append(errs, fmt.Errorf("unsupported doc expansion %q", c.DocExpansion))
+	}
+
+	if c.Layout != BaseLayout && c.Layout != StandaloneLayout {
+		errs = append(errs, fmt.Errorf("unsupported layout %q", c.Layout))
+	}
+
+	if !domIDPattern.MatchString(c.DomID) {
+		errs = append(errs, fmt.Errorf("invalid DOM id %q", c.DomID))
+	}
+
+	if c.DefaultModelsExpandDepth < HideModel {
+		errs = append(errs, fmt.Errorf("invalid default models expand depth %d", c.DefaultModelsExpandDepth))
+	}
+
+	if c.DefaultModelExpandDepth != nil && *c.DefaultModelExpandDepth < -1 {
+		errs = append(errs, fmt.Errorf("invalid default model expand depth %d", *c.DefaultModelExpandDepth))
+	}
+
+	if err := validateURL(c.URL); err != nil {
+		errs = append(errs, fmt.Errorf("URL: %w", err))
+	}
+
+	errs = append(errs, c.validateURLs()...)
+
+	switch c.OpenAPIVersion {
+	case "", OpenAPI30, OpenAPI31:
+	default:
+		errs = append(errs, fmt.Errorf("unsupported OpenAPI version %q", c.OpenAPIVersion))
+	}
+
+	seen := make(map[Encoding]bool, len(c.Encodings))
+	for _, enc := range c.Encodings {
+		switch {
+		case enc != Gzip && enc != Brotli:
+			errs = append(errs, fmt.Errorf("unsupported encoding %q", enc))
+		case seen[enc]:
+			errs = append(errs, fmt.Errorf("duplicate encoding %q", enc))
+		}
+
+		seen[enc] = true
+	}
+
+	for _, icon := range []string{c.Favicon32, c.Favicon16} {
+		if icon == "" {
+			continue
+		}
+
+		if err := validateURL(icon); err != nil {
+			errs = append(errs, fmt.Errorf("favicon: %w", err))
+		}
+	}
+
+	for _, stylesheet := range c.Stylesheets {
+		if err := validateURL(stylesheet); err != nil {
+			errs = append(errs, fmt.Errorf("stylesheet: %w", err))
+		}
+	}
+
+	if c.RendererAssets != "" {
+		if err := validateURL(c.RendererAssets); err != nil {
+			errs = append(errs, fmt.Errorf("renderer assets: %w", err))
+		}
+	}
+
+	errs = append(errs, c.validateRenderer()...)
+
+	for _, name := range sortedKeys(c.RendererIntegrity) {
+		if !integrityPattern.MatchString(c.RendererIntegrity[name]) {
+			errs = append(errs, fmt.Errorf("renderer asset %s: invalid integrity %q", name, c.RendererIntegrity[name]))
+		}
+	}
+
+	if c.OAuth != nil && c.OAuth.RedirectURL != "" {
+		if err := validateURL(c.OAuth.RedirectURL); err != nil {
+			errs = append(errs, fmt.Errorf("OAuth redirect URL: %w", err))
+		}
+	}
+
+	errs = append(errs, c.validateUIConfig()...)
+
+	if _, err := parseTrustedProxies(c.TrustedProxies); err != nil {
+		errs = append(errs, fmt.Errorf("trusted proxies: %w", err))
+	}
+
+	return errs
+}
+
+// validateURLs checks the definitions listed in URLs and the selected PrimaryName.
+func (c *Config) validateURLs() []error {
+	var errs []error
+
+	names := make(map[string]bool, len(c.URLs))
+	// the definitions by name of the document they are served at
+	served := make(map[string]string, len(c.URLs))
+
+	for _, u := range c.URLs {
+		if u.InstanceName != "" {
+			first, ok := served[u.InstanceName]
+
+			switch {
+			case c.reservedName(u.InstanceName):
+				errs = append(errs, fmt.Errorf("definition %q: name %q is reserved", u.Name, u.InstanceName))
+			case ok:
+				errs = append(errs, fmt.Errorf("definition %q: document %s.json already served for definition %q", u.Name, u.InstanceName, first))
+			default:
+				served[u.InstanceName] = u.Name
+			}
+		}
+
+		switch {
+		case u.Name == "":
+			errs = append(errs, fmt.Errorf("definition %q has no name", u.URL))
+		case names[u.Name]:
+			errs = append(errs, fmt.Errorf("duplicate definition name %q", u.Name))
+		}
+
+		names[u.Name] = true
+
+		if err := validateURL(u.URL); err != nil {
+			errs = append(errs, fmt.Errorf("definition %q: %w", u.Name, err))
+		}
+	}
+
+	switch {
+	case c.PrimaryName == "":
+	case len(c.URLs) == 0:
+		errs = append(errs, fmt.Errorf("primary name %q requires URLs", c.PrimaryName))
+	case !names[c.PrimaryName]:
+		errs = append(errs, fmt.Errorf("primary name %q does not match any definition", c.PrimaryName))
+	}
+
+	return errs
+}
+
+// reservedNames are the names of the routes of the handler, which the documents
+// listed in URLs cannot be served at.
+var reservedNames = map[string]bool{
+	"doc":                 true,
+	"validation":          true,
+	"swagger-config":      true,
+	"swagger-initializer": true,
+	"hot-reload":          true,
+}
+
+// reservedName reports whether name is the name of a route of the handler.
+func (c *Config) reservedName(name string) bool {
+	return reservedNames[name] || name == "openapi" && c.OpenAPIVersion != ""
+}
+
+// validateRenderer checks that the settings of the index page are supported by the
+// renderer: the ReDoc, RapiDoc and Elements pages ignore the Swagger UI ones, and
+// inject inline styles which a strict Content-Security-Policy would block.
+func (c *Config) validateRenderer() []error {
+	if c.Renderer == "" || c.Renderer == SwaggerUIRenderer {
+		return nil
+	}
+
+	var errs []error
+
+	for _, setting := range []struct {
+		name string
+		set  bool
+	}{
+		{"StrictCSP", c.StrictCSP},
+		{"Theme", c.Theme != ""},
+		{"ThemeSwitcher", c.ThemeSwitcher},
+		{"OAuth", c.OAuth != nil},
+		{"ExternalInitializer", c.ExternalInitializer},
+		{"BeforeScript", c.BeforeScript != ""},
+		{"AfterScript", c.AfterScript != ""},
+		{"Plugins", len(c.Plugins) > 0},
+		{"UIConfig", len(c.UIConfig) > 0},
+	} {
+		if setting.set {
+			errs = append(errs, fmt.Errorf("%s is not supported by the %s renderer", setting.name, c.Renderer))
+		}
+	}
+
+	return errs
+}
+
+// validateUIConfig checks the UIConfig keys, which must not conflict with the typed
+// settings, and values, which must not be empty.
+func (c *Config) validateUIConfig() []error {
+	typed := make(map[string]bool)
+	for _, setting := range c.uiSettings() {
+		typed[setting.Key] = true
+	}
+
+	keys := make([]string, 0, len(c.UIConfig))
+	for k := range c.UIConfig {
+		keys = append(keys, string(k))
+	}
+
+	sort.Strings(keys)
+
+	var errs []error
+
+	for _, k := range keys {
+		key, ok := uiConfigKey(template.JS(k))
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("UIConfig key %s is neither an identifier nor a quoted string", k))
+		case typed[key]:
+			errs = append(errs, fmt.Errorf("UIConfig key %s conflicts with a typed option", k))
+		case strings.TrimSpace(string(c.UIConfig[template.JS(k)])) == "":
+			errs = append(errs, fmt.Errorf("UIConfig key %s has an empty value", k))
+		}
+	}
+
+	return errs
+}
+
+// validateURL checks that s is an absolute URL with a host, e.g.
+// `https://example.org/doc.json`, or a reference to a path, e.g. `/doc.json` or
+// `./doc.json`.
+func validateURL(s string) error {
+	if s == "" {
+		return fmt.Errorf("empty URL")
+	}
+
+	u, err := url.Parse(s)
+
+	switch {
+	case err != nil:
+		return err
+	case u.Scheme != "" && u.Host == "":
+		return fmt.Errorf("URL %q has no host", s)
+	case u.Host == "" && u.Path == "":
+		return fmt.Errorf("URL %q has no path", s)
+	case strings.ContainsAny(s, " \t\r\n"):
+		return fmt.Errorf("URL %q contains white space", s)
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package httpSwagger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHandler(t *testing.T) {
+	h, err := NewHandler(
+		URLs(SpecURL{Name: "Users", InstanceName: "users"}, SpecURL{Name: "Pets", URL: "https://example.org/pets.json"}),
+		PrimaryName("Pets"),
+		DocExpansion("none"),
+		Layout(BaseLayout),
+		DefaultModelsExpandDepth(HideModel),
+		Compression(Gzip),
+		RewriteServer("10.0.0.0/8"),
+		UIConfig(map[string]string{"showExtensions": "true"}),
+	)
+	assert.NoError(t, err)
+
+	router := http.NewServeMux()
+	router.Handle("/swagger/", h)
+
+	w := performRequest(http.MethodGet, "/swagger/index.html", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestNewHandlerErrors(t *testing.T) {
+	h, err := NewHandler(
+		DocExpansion("all"),
+		Layout("TopbarLayout"),
+		DomID("#swagger-ui"),
+		DefaultModelsExpandDepth(-2),
+		URL(""),
+		URLs(SpecURL{Name: "Users", URL: "users.json"}, SpecURL{Name: "Users", URL: "%zz"}, SpecURL{URL: "pets.json"}),
+		PrimaryName("Orders"),
+		OpenAPI3("3.2.0"),
+		Compression(Gzip, "zstd", Gzip),
+		RewriteServer("10.0.0.0/33"),
+		Filter(true),
+		UIConfig(map[string]string{"filter": "false", "a-b": "1"}),
+		TagsSorter("length"),
+	)
+	assert.Nil(t, h)
+	assert.EqualError(t, err, `invalid configuration: unsupported tags sorter "length"; `+
+		`unsupported doc expansion "all"; unsupported layout "TopbarLayout"; invalid DOM id "#swagger-ui"; `+
+		`invalid default models expand depth -2; URL: empty URL; duplicate definition name "Users"; `+
+		`definition "Users": parse "%zz": invalid URL escape "%zz"; definition "pets.json" has no name; `+
+		`primary name "Orders" does not match any definition; unsupported OpenAPI version "3.2.0"; `+
+		`unsupported encoding "zstd"; duplicate encoding "gzip"; `+
+		`UIConfig key a-b is neither an identifier nor a quoted string; UIConfig key filter conflicts with a typed option; `+
+		`trusted proxies: invalid CIDR address: 10.0.0.0/33`)
+
+	var cfgErr ConfigError
+	assert.True(t, errors.As(err, &cfgErr))
+	assert.Len(t, cfgErr, 16)
+
+	_, err = NewHandler(PrimaryName("Users"))
+	assert.EqualError(t, err, `invalid configuration: primary name "Users" requires URLs`)
+
+	// documents are not read for invalid configurations
+	reads := 0
+	_, err = NewHandler(
+		Provider(DocProviderFunc(func(context.Context) ([]byte, string, error) {
+			reads++
+
+			return []byte(`{"swagger":"2.0"}`), "application/json", nil
+		})),
+		Validation(ReportValidation),
+		DocExpansion("all"),
+	)
+	assert.EqualError(t, err, `invalid configuration: unsupported doc expansion "all"`)
+	assert.Zero(t, reads)
+}
+
+func TestValidateURL(t *testing.T) {
+	for _, valid := range []string{"doc.json", "./doc.json", "../v1/doc.json", "/swagger/doc.json", "https://example.org/doc.json", "//cdn.example.org/", "doc.json?v=1"} {
+		assert.NoError(t, validateURL(valid), valid)
+	}
+
+	for invalid, err := range map[string]string{
+		"":                    "empty URL",
+		"%zz":                 `parse "%zz": invalid URL escape "%zz"`,
+		"https:doc.json":      `URL "https:doc.json" has no host`,
+		"javascript:alert(1)": `URL "javascript:alert(1)" has no host`,
+		"?v=1":                `URL "?v=1" has no path`,
+		"my docs.json":        `URL "my docs.json" contains white space`,
+	} {
+		assert.EqualError(t, validateURL(invalid), err, invalid)
+	}
+}
+
+func TestHandlerCompatibility(t *testing.T) {
+	var logs bytes.Buffer
+
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	router := http.NewServeMux()
+	router.Handle("/swagger/", Handler(DocExpansion("all"), DomID("#swagger-ui")))
+	assert.Empty(t, logs.String())
+
+	router.Handle("/proxies/", Handler(RewriteServer("not-an-ip")))
+	assert.Contains(t, logs.String(), "httpSwagger: invalid configuration: trusted proxies: invalid IP address: not-an-ip\n")
+
+	w := performRequest(http.MethodGet, "/swagger/index.html", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// the problems are not disclosed to the clients
+	w = performRequest(http.MethodGet, "/proxies/index.html", router)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "Internal Server Error\n", w.Body.String())
+}
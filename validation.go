@@ -0,0 +1,507 @@
+package httpSwagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ValidationMode controls the validation of the documents when the handler is created.
+type ValidationMode string
+
+const (
+	// ReportValidation serves the problems found at `validation.json` and shows a
+	// warning banner on the index page.
+	ReportValidation ValidationMode = "report"
+	// FailFastValidation reports the problems found as configuration errors.
+	FailFastValidation ValidationMode = "fail-fast"
+)
+
+// Validation checks the served documents when the handler is created. Swagger 2.0 and
+// OpenAPI 3.0 documents are validated against the JSON schema of their version, except
+// for the formats, the OpenAPI 3.0 schema being embedded by go generate. The documents
+// are also checked for dangling `$ref`s, duplicate operationIds, undefined security
+// schemes and undeclared path parameters, which the schemas do not cover, and the
+// OpenAPI 3.1 documents, whose schema is of a later JSON Schema dialect, for a missing
+// info title or version and malformed paths, operations and parameters. With Audience,
+// the report covers the unfiltered documents, so it is neither served nor shown on the
+// index page.
+func Validation(mode ValidationMode) func(*Config) {
+	return func(c *Config) {
+		if mode != ReportValidation && mode != FailFastValidation {
+			c.errs = append(c.errs, fmt.Errorf("unsupported validation mode %q", mode))
+
+			return
+		}
+
+		c.Validation = mode
+	}
+}
+
+// ValidationIssue is a problem found in a document.
+type ValidationIssue struct {
+	// Document is the file name the document is served at, e.g. `doc.json`.
+	Document string `json:"document"`
+	// Pointer is the JSON pointer of the invalid value.
+	Pointer string `json:"pointer"`
+	Message string `json:"message"`
+}
+
+func (i ValidationIssue) Error() string {
+	return i.Document + "#" + i.Pointer + ": " + i.Message
+}
+
+// ValidationReport lists the problems found in the documents.
+type ValidationReport struct {
+	Valid  bool              `json:"valid"`
+	Issues []ValidationIssue `json:"issues"`
+}
+
+// reportedValidation returns the validation report shown to the callers, nil when the
+// documents are filtered per audience, as it covers the unfiltered ones.
+func (h *handler) reportedValidation() *ValidationReport {
+	if h.config.Audience != nil {
+		return nil
+	}
+
+	return h.validation
+}
+
+// ValidationFailed reports whether the validation of the documents found problems.
+func (d IndexData) ValidationFailed() bool {
+	return d.Validation != nil && !d.Validation.Valid
+}
+
+// validateDocs validates the documents read by docs, named after their key.
+func validateDocs(names []string, docs map[string]docReader) *ValidationReport {
+	r, _ := http.NewRequest(http.MethodGet, "/", nil)
+
+	report := &ValidationReport{Issues: []ValidationIssue{}}
+
+	for _, name := range names {
+		doc, err := docs[name](r)
+		if err != nil {
+			report.Issues = append(report.Issues, ValidationIssue{
+				Document: name + ".json",
+				Message:  fmt.Sprintf("cannot read document: %v", err),
+			})
+
+			continue
+		}
+
+		report.Issues = append(report.Issues, validateDocument(name+".json", doc)...)
+	}
+
+	report.Valid = len(report.Issues) == 0
+
+	return report
+}
+
+var pathParamPattern = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// docValidator collects the problems found in a document.
+type docValidator struct {
+	name string
+	root map[string]interface{}
+	oas3 bool
+	// schemaChecked reports whether the document was validated against the JSON
+	// schema of its version.
+	schemaChecked bool
+	issues        []ValidationIssue
+}
+
+func (v *docValidator) add(pointer, format string, args ...interface{}) {
+	v.issues = append(v.issues, ValidationIssue{
+		Document: v.name,
+		Pointer:  pointer,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// addStructural adds a problem which the JSON schema of the document reports, unless
+// the document was validated against it.
+func (v *docValidator) addStructural(pointer, format string, args ...interface{}) {
+	if !v.schemaChecked {
+		v.add(pointer, format, args...)
+	}
+}
+
+// validateDocument returns the problems found in the JSON document doc.
+func validateDocument(name string, doc []byte) []ValidationIssue {
+	v := &docValidator{name: name}
+
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+
+	var root interface{}
+	if err := dec.Decode(&root); err != nil {
+		v.add("", "malformed JSON: %v", err)
+
+		return v.issues
+	}
+
+	var ok bool
+	if v.root, ok = root.(map[string]interface{}); !ok {
+		v.add("", "document is not an object")
+
+		return v.issues
+	}
+
+	v.checkVersion()
+	v.checkSchema()
+	v.checkInfo()
+	v.checkPaths()
+	v.checkRefs("", v.root)
+
+	return v.issues
+}
+
+func (v *docValidator) checkVersion() {
+	if version, ok := v.root["openapi"]; ok {
+		if s, _ := version.(string); !strings.HasPrefix(s, "3.") {
+			v.add("/openapi", "unsupported OpenAPI version %v", version)
+		}
+
+		v.oas3 = true
+
+		return
+	}
+
+	version, ok := v.root["swagger"]
+	if !ok {
+		v.add("", "missing swagger or openapi version")
+	} else if version != "2.0" {
+		v.add("/swagger", "unsupported Swagger version %v", version)
+	}
+}
+
+// checkSchema validates the document against the JSON schema of its version, if embedded.
+func (v *docValidator) checkSchema() {
+	version, _ := v.root["swagger"].(string)
+	if openapi, ok := v.root["openapi"].(string); ok {
+		version = strings.Join(strings.SplitN(openapi, ".", 3)[:2], ".")
+	}
+
+	schema, ok := documentSchemas[version]
+	if !ok {
+		return
+	}
+
+	for _, err := range schema.validate(v.root) {
+		v.add(err.pointer, "%s", err.message)
+	}
+
+	v.schemaChecked = true
+}
+
+func (v *docValidator) checkInfo() {
+	info, ok := v.root["info"].(map[string]interface{})
+	if !ok {
+		v.addStructural("/info", "missing info object")
+
+		return
+	}
+
+	for _, field := range []string{"title", "version"} {
+		if s, _ := info[field].(string); s == "" {
+			v.addStructural("/info/"+field, "missing %s", field)
+		}
+	}
+}
+
+func (v *docValidator) checkPaths() {
+	raw, ok := v.root["paths"]
+	if !ok {
+		// OpenAPI 3.1 documents may only hold webhooks or components
+		if openapi, _ := v.root["openapi"].(string); !strings.HasPrefix(openapi, "3.1") {
+			v.addStructural("/paths", "missing paths object")
+		}
+
+		return
+	}
+
+	paths, ok := raw.(map[string]interface{})
+	if !ok {
+		v.addStructural("/paths", "paths is not an object")
+
+		return
+	}
+
+	schemes := v.securitySchemes()
+	v.checkSecurity("/security", v.root["security"], schemes)
+
+	operationIDs := make(map[string]string)
+
+	for _, path := range sortedKeys(paths) {
+		pointer := "/paths/" + escapePointer(path)
+
+		if !strings.HasPrefix(path, "/") {
+			v.addStructural(pointer, "path must begin with a slash")
+		}
+
+		item, ok := paths[path].(map[string]interface{})
+		if !ok {
+			v.addStructural(pointer, "path item is not an object")
+
+			continue
+		}
+
+		pathParams := v.checkParameters(pointer+"/parameters", item["parameters"])
+
+		for _, method := range sortedKeys(item) {
+			if !isOperationMethod(method) {
+				continue
+			}
+
+			opPointer := pointer + "/" + method
+
+			op, ok := item[method].(map[string]interface{})
+			if !ok {
+				v.addStructural(opPointer, "operation is not an object")
+
+				continue
+			}
+
+			if responses, ok := op["responses"].(map[string]interface{}); !ok || len(responses) == 0 {
+				v.addStructural(opPointer+"/responses", "missing responses")
+			}
+
+			if id, ok := op["operationId"].(string); ok {
+				if first, dup := operationIDs[id]; dup {
+					v.add(opPointer+"/operationId", "duplicate operationId %q, also used at %s", id, first)
+				} else {
+					operationIDs[id] = opPointer
+				}
+			}
+
+			v.checkSecurity(opPointer+"/security", op["security"], schemes)
+
+			declared := make(map[string]bool, len(pathParams))
+			for p := range pathParams {
+				declared[p] = true
+			}
+
+			for p := range v.checkParameters(opPointer+"/parameters", op["parameters"]) {
+				declared[p] = true
+			}
+
+			v.checkPathParameters(opPointer, path, declared)
+		}
+	}
+}
+
+// checkParameters checks the parameters at pointer, returning the names of the path parameters.
+func (v *docValidator) checkParameters(pointer string, raw interface{}) map[string]bool {
+	pathParams := make(map[string]bool)
+	if raw == nil {
+		return pathParams
+	}
+
+	params, ok := raw.([]interface{})
+	if !ok {
+		v.addStructural(pointer, "parameters is not an array")
+
+		return pathParams
+	}
+
+	locations := "query header path formData body"
+	if v.oas3 {
+		locations = "query header path cookie"
+	}
+
+	for i, raw := range params {
+		paramPointer := fmt.Sprintf("%s/%d", pointer, i)
+
+		param, ok := v.resolve(raw).(map[string]interface{})
+		if !ok {
+			// unresolved references are reported by checkRefs
+			if o, isObject := raw.(map[string]interface{}); !isObject || o["$ref"] == nil {
+				v.addStructural(paramPointer, "parameter is not an object")
+			}
+
+			continue
+		}
+
+		name, _ := param["name"].(string)
+		if name == "" {
+			v.addStructural(paramPointer+"/name", "missing parameter name")
+		}
+
+		in, _ := param["in"].(string)
+		if !containsWord(locations, in) {
+			v.addStructural(paramPointer+"/in", "invalid parameter location %q", in)
+		}
+
+		if in == "path" {
+			if required, _ := param["required"].(bool); !required {
+				v.addStructural(paramPointer+"/required", "path parameter %q must be required", name)
+			}
+
+			pathParams[name] = true
+		}
+	}
+
+	return pathParams
+}
+
+// checkPathParameters checks that the parameters of the path template are the declared ones.
+func (v *docValidator) checkPathParameters(pointer, path string, declared map[string]bool) {
+	inPath := make(map[string]bool)
+
+	for _, m := range pathParamPattern.FindAllStringSubmatch(path, -1) {
+		inPath[m[1]] = true
+
+		if !declared[m[1]] {
+			v.add(pointer, "path parameter %q is not declared", m[1])
+		}
+	}
+
+	for _, name := range sortedKeys(declared) {
+		if !inPath[name] {
+			v.add(pointer, "path parameter %q is not in the path", name)
+		}
+	}
+}
+
+// securitySchemes returns the names of the security schemes defined by the document.
+func (v *docValidator) securitySchemes() map[string]bool {
+	defs, _ := v.root["securityDefinitions"].(map[string]interface{})
+	if v.oas3 {
+		components, _ := v.root["components"].(map[string]interface{})
+		defs, _ = components["securitySchemes"].(map[string]interface{})
+	}
+
+	schemes := make(map[string]bool, len(defs))
+	for name := range defs {
+		schemes[name] = true
+	}
+
+	return schemes
+}
+
+// checkSecurity checks that the security requirements at pointer use defined schemes.
+func (v *docValidator) checkSecurity(pointer string, raw interface{}, schemes map[string]bool) {
+	requirements, _ := raw.([]interface{})
+
+	for i, raw := range requirements {
+		requirement, ok := raw.(map[string]interface{})
+		if !ok {
+			v.addStructural(fmt.Sprintf("%s/%d", pointer, i), "security requirement is not an object")
+
+			continue
+		}
+
+		for _, name := range sortedKeys(requirement) {
+			if !schemes[name] {
+				v.add(fmt.Sprintf("%s/%d", pointer, i), "undefined security scheme %q", name)
+			}
+		}
+	}
+}
+
+// checkRefs reports the local references of value which do not resolve.
+func (v *docValidator) checkRefs(pointer string, value interface{}) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := value["$ref"].(string); ok && strings.HasPrefix(ref, "#") {
+			if _, ok := resolvePointer(v.root, ref); !ok {
+				v.add(pointer+"/$ref", "unresolved reference %q", ref)
+			}
+		}
+
+		for _, k := range sortedKeys(value) {
+			v.checkRefs(pointer+"/"+escapePointer(k), value[k])
+		}
+	case []interface{}:
+		for i, child := range value {
+			v.checkRefs(fmt.Sprintf("%s/%d", pointer, i), child)
+		}
+	}
+}
+
+// resolve returns the value referenced by value when it is a local reference, else value.
+func (v *docValidator) resolve(value interface{}) interface{} {
+	o, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+
+	ref, ok := o["$ref"].(string)
+	if !ok {
+		return value
+	}
+
+	resolved, _ := resolvePointer(v.root, ref)
+
+	return resolved
+}
+
+// resolvePointer returns the value of root referenced by the local reference ref.
+func resolvePointer(root interface{}, ref string) (interface{}, bool) {
+	pointer := strings.TrimPrefix(ref, "#")
+	if pointer == "" {
+		return root, true
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false
+	}
+
+	value := root
+
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			child, ok := v[token]
+			if !ok {
+				return nil, false
+			}
+
+			value = child
+		case []interface{}:
+			var i int
+			if _, err := fmt.Sscan(token, &i); err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+
+			value = v[i]
+		default:
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+
+	switch m := m.(type) {
+	case map[string]interface{}:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]bool:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]string:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,140 @@
+package httpSwagger
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDocument(t *testing.T) {
+	tests := []struct {
+		desc string
+		doc  string
+		exp  []string
+	}{
+		{
+			desc: "valid",
+			doc: `{"swagger":"2.0","info":{"title":"Petstore","version":"1.0"},
+				"securityDefinitions":{"apiKey":{"type":"apiKey","name":"key","in":"header"}},
+				"security":[{"apiKey":[]}],
+				"parameters":{"id":{"name":"id","in":"path","required":true,"type":"string"}},
+				"paths":{"/pets/{id}":{"parameters":[{"$ref":"#/parameters/id"}],
+					"get":{"operationId":"getPet","responses":{"200":{"description":"ok","schema":{"$ref":"#/definitions/Pet"}}}}}},
+				"definitions":{"Pet":{"type":"object"}}}`,
+		},
+		{
+			desc: "malformed",
+			doc:  `{"swagger":"2.0",`,
+			exp:  []string{"doc.json#: malformed JSON: unexpected EOF"},
+		},
+		{
+			desc: "not an object",
+			doc:  `[]`,
+			exp:  []string{"doc.json#: document is not an object"},
+		},
+		{
+			desc: "swagger 2.0 schema",
+			doc: `{"swagger":"2.0","info":{"title":"Petstore"},"basePath":"api",
+				"paths":{"pets":{},"/pets":{"get":{"parameters":[{"name":"q","in":"cookie","type":"string"}]}}}}`,
+			exp: []string{
+				`doc.json#/basePath: value "api" does not match pattern "^/"`,
+				`doc.json#/info: missing property "version"`,
+				`doc.json#/paths/~1pets/get: missing property "responses"`,
+				`doc.json#/paths/~1pets/get/parameters/0/in: value "cookie" is not one of ["header","formData","query"]`,
+				`doc.json#/paths: property "pets" is not allowed`,
+			},
+		},
+		{
+			desc: "lint",
+			doc:  `{"swagger":"3.0","info":{"title":""},"paths":{"pets":{"get":{"parameters":[{"name":"q","in":"cookie"}]}}}}`,
+			exp: []string{
+				`doc.json#/swagger: unsupported Swagger version 3.0`,
+				`doc.json#/info/title: missing title`,
+				`doc.json#/info/version: missing version`,
+				`doc.json#/paths/pets: path must begin with a slash`,
+				`doc.json#/paths/pets/get/responses: missing responses`,
+				`doc.json#/paths/pets/get/parameters/0/in: invalid parameter location "cookie"`,
+			},
+		},
+		{
+			desc: "references, operation ids, security and path parameters",
+			doc: `{"openapi":"3.0.3","info":{"title":"Petstore","version":"1.0"},
+				"security":[{"oauth":[]}],
+				"paths":{
+					"/pets/{id}":{"get":{"operationId":"getPet","security":[{"apiKey":[]}],
+						"parameters":[{"name":"petId","in":"path","required":false}],
+						"responses":{"200":{"$ref":"#/components/responses/Pet"}}}},
+					"/users":{"get":{"operationId":"getPet","parameters":[{"$ref":"#/components/parameters/Missing"}],
+						"responses":{"200":{"description":"ok"}}}}}}`,
+			exp: []string{
+				`doc.json#/security/0: undefined security scheme "oauth"`,
+				`doc.json#/paths/~1pets~1{id}/get/security/0: undefined security scheme "apiKey"`,
+				`doc.json#/paths/~1pets~1{id}/get/parameters/0/required: path parameter "petId" must be required`,
+				`doc.json#/paths/~1pets~1{id}/get: path parameter "id" is not declared`,
+				`doc.json#/paths/~1pets~1{id}/get: path parameter "petId" is not in the path`,
+				`doc.json#/paths/~1users/get/operationId: duplicate operationId "getPet", also used at /paths/~1pets~1{id}/get`,
+				`doc.json#/paths/~1pets~1{id}/get/responses/200/$ref: unresolved reference "#/components/responses/Pet"`,
+				`doc.json#/paths/~1users/get/parameters/0/$ref: unresolved reference "#/components/parameters/Missing"`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var issues []string
+			for _, issue := range validateDocument("doc.json", []byte(test.doc)) {
+				issues = append(issues, issue.Error())
+			}
+
+			assert.Equal(t, test.exp, issues)
+		})
+	}
+}
+
+func TestValidation(t *testing.T) {
+	invalid := BytesProvider([]byte(`{"swagger":"2.0","info":{"title":"Petstore","version":"1.0"},"paths":{"/pets":{}},"x-ref":{"$ref":"#/nowhere"}}`), "")
+
+	router := http.NewServeMux()
+	router.Handle("/report/", Handler(Provider(invalid), Validation(ReportValidation)))
+	router.Handle("/valid/", Handler(
+		Provider(BytesProvider([]byte(`{"swagger":"2.0","info":{"title":"Petstore","version":"1.0"},"paths":{}}`), "")),
+		Validation(ReportValidation),
+	))
+	router.Handle("/disabled/", Handler(Provider(invalid)))
+	router.Handle("/audience/", Handler(
+		Provider(invalid),
+		Validation(ReportValidation),
+		Audience(AudienceConfig{Audience: func(*http.Request) ([]string, error) { return nil, nil }}),
+	))
+
+	w := performRequest(http.MethodGet, "/report/validation.json", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `{"valid":false,"issues":[{"document":"doc.json","pointer":"/x-ref/$ref","message":"unresolved reference \"#/nowhere\""}]}`, w.Body.String())
+
+	w = performRequest(http.MethodGet, "/report/index.html", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "    .validation-banner\n")
+	assert.Contains(t, w.Body.String(), "</svg>\n\n<div class=\"validation-banner\" role=\"alert\">\n"+
+		"  The API definition has 1 validation issue(s), see <a href=\"./validation.json\">validation.json</a>.\n"+
+		"</div>\n<div id=\"swagger-ui\"></div>")
+
+	w = performRequest(http.MethodGet, "/valid/validation.json", router)
+	assert.Equal(t, `{"valid":true,"issues":[]}`, w.Body.String())
+
+	w = performRequest(http.MethodGet, "/valid/index.html", router)
+	assert.NotContains(t, w.Body.String(), "validation-banner")
+
+	assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/disabled/validation.json", router).Code)
+
+	// the report covers the unfiltered documents
+	assert.Equal(t, http.StatusNotFound, performRequest(http.MethodGet, "/audience/validation.json", router).Code)
+	assert.NotContains(t, performRequest(http.MethodGet, "/audience/index.html", router).Body.String(), `class="validation-banner"`)
+
+	_, err := NewHandler(Provider(invalid), Validation(FailFastValidation))
+	assert.EqualError(t, err, `invalid configuration: doc.json#/x-ref/$ref: unresolved reference "#/nowhere"`)
+
+	_, err = NewHandler(Provider(invalid), Validation("strict"))
+	assert.EqualError(t, err, `invalid configuration: unsupported validation mode "strict"`)
+}
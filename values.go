@@ -0,0 +1,137 @@
+package httpSwagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// UIValue is a typed Swagger UI configuration value. Values are JSON-encoded into the
+// page, except those created with RawJS.
+type UIValue struct {
+	js  template.JS
+	err error
+}
+
+// StringValue returns a string UIValue.
+func StringValue(s string) UIValue {
+	return JSONValue(s)
+}
+
+// BoolValue returns a boolean UIValue.
+func BoolValue(b bool) UIValue {
+	return JSONValue(b)
+}
+
+// NumberValue returns a number UIValue. NaN and infinities are invalid.
+func NumberValue(n float64) UIValue {
+	return JSONValue(n)
+}
+
+// JSONValue returns a UIValue holding the JSON encoding of v, typically an object or an array.
+func JSONValue(v interface{}) UIValue {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return UIValue{err: err}
+	}
+
+	return UIValue{js: template.JS(b)}
+}
+
+// RawJS returns a UIValue inserted into the page as is, e.g. a function.
+// It must never hold untrusted input.
+func RawJS(js string) UIValue {
+	if strings.TrimSpace(js) == "" {
+		return UIValue{err: fmt.Errorf("empty raw JavaScript value")}
+	}
+
+	return UIValue{js: template.JS(js)}
+}
+
+// UIConfigValues sets additional SwaggerUIBundle config object properties from typed values.
+// Keys are JSON-encoded, so any property name such as `urls.primaryName` is valid. Invalid
+// values are reported as configuration errors.
+func UIConfigValues(values map[string]UIValue) func(*Config) {
+	return func(c *Config) {
+		if c.UIConfig == nil {
+			c.UIConfig = make(map[template.JS]template.JS, len(values))
+		}
+
+		for k, v := range values {
+			if v.err != nil {
+				c.errs = append(c.errs, fmt.Errorf("UIConfig %q: %w", k, v.err))
+
+				continue
+			}
+
+			// the zero UIValue holds no value
+			if v.js == "" {
+				c.errs = append(c.errs, fmt.Errorf("UIConfig %q: empty value", k))
+
+				continue
+			}
+
+			key, _ := json.Marshal(k)
+			c.UIConfig[template.JS(key)] = v.js
+		}
+	}
+}
+
+// PluginNames specifies additional plugins to load into Swagger UI by name. Names must be
+// JavaScript identifiers or property paths such as `SwaggerUIBundle.plugins.DownloadUrl`,
+// anything else is reported as a configuration error.
+func PluginNames(names ...string) func(*Config) {
+	return func(c *Config) {
+		for _, name := range names {
+			if !isPropertyPath(name) {
+				c.errs = append(c.errs, fmt.Errorf("plugin %q is not a JavaScript identifier", name))
+
+				continue
+			}
+
+			c.Plugins = append(c.Plugins, template.JS(name))
+		}
+	}
+}
+
+func isPropertyPath(s string) bool {
+	for _, part := range strings.Split(s, ".") {
+		if !isIdentifier(part) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ConfigError reports every problem found in a configuration.
+type ConfigError []error
+
+func (e ConfigError) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return "invalid configuration: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the problems found in the configuration.
+func (e ConfigError) Unwrap() []error {
+	return e
+}
+
+// newConfigError returns a ConfigError holding errs, or nil when there are none.
+func newConfigError(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return ConfigError(errs)
+}
+
+// err returns the problems found while applying the configuration options, if any.
+func (c *Config) err() error {
+	return newConfigError(c.errs)
+}
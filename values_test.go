@@ -0,0 +1,101 @@
+package httpSwagger
+
+import (
+	"html/template"
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUIValues(t *testing.T) {
+	tests := []struct {
+		desc  string
+		value UIValue
+		exp   template.JS
+		err   bool
+	}{
+		{desc: "string", value: StringValue(`abc "quoted" </script>`), exp: `"abc \"quoted\" \u003c/script\u003e"`},
+		{desc: "bool", value: BoolValue(true), exp: `true`},
+		{desc: "number", value: NumberValue(1.5), exp: `1.5`},
+		{desc: "NaN", value: NumberValue(math.NaN()), err: true},
+		{desc: "object", value: JSONValue(map[string]interface{}{"activated": false}), exp: `{"activated":false}`},
+		{desc: "array", value: JSONValue([]string{"get", "post"}), exp: `["get","post"]`},
+		{desc: "unsupported", value: JSONValue(func() {}), err: true},
+		{desc: "raw", value: RawJS(`() => { window.ui.setBasePath('v3'); }`), exp: `() => { window.ui.setBasePath('v3'); }`},
+		{desc: "empty raw", value: RawJS(" "), err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.exp, test.value.js)
+			assert.Equal(t, test.err, test.value.err != nil)
+		})
+	}
+}
+
+func TestUIConfigValues(t *testing.T) {
+	cfg := newConfig(
+		UIConfig(map[string]string{"showExtensions": "true"}),
+		UIConfigValues(map[string]UIValue{
+			"filter":           StringValue("abc"),
+			"urls.primaryName": StringValue("Users"),
+			"onComplete":       RawJS(`() => {}`),
+		}),
+	)
+	assert.NoError(t, cfg.err())
+	assert.Equal(t, map[template.JS]template.JS{
+		"showExtensions":     "true",
+		`"filter"`:           `"abc"`,
+		`"urls.primaryName"`: `"Users"`,
+		`"onComplete"`:       `() => {}`,
+	}, cfg.UIConfig)
+
+	cfg = newConfig(UIConfigValues(map[string]UIValue{
+		"maxDisplayedTags": NumberValue(math.Inf(1)),
+	}))
+	assert.EqualError(t, cfg.err(), `invalid configuration: UIConfig "maxDisplayedTags": json: unsupported value: +Inf`)
+	assert.Empty(t, cfg.UIConfig)
+
+	cfg = newConfig(UIConfigValues(map[string]UIValue{"filter": {}}))
+	assert.EqualError(t, cfg.err(), `invalid configuration: UIConfig "filter": empty value`)
+	assert.Empty(t, cfg.UIConfig)
+
+	_, err := NewHandler(UIConfig(map[string]string{"filter": " "}))
+	assert.EqualError(t, err, `invalid configuration: UIConfig key filter has an empty value`)
+}
+
+func TestPluginNames(t *testing.T) {
+	cfg := newConfig(PluginNames("SomePlugin", "SwaggerUIBundle.plugins.DownloadUrl"))
+	assert.NoError(t, cfg.err())
+	assert.Equal(t, []template.JS{"SomePlugin", "SwaggerUIBundle.plugins.DownloadUrl"}, cfg.Plugins)
+
+	cfg = newConfig(PluginNames("SomePlugin", "alert(1)", "a..b"))
+	assert.EqualError(t, cfg.err(), `invalid configuration: plugin "alert(1)" is not a JavaScript identifier; plugin "a..b" is not a JavaScript identifier`)
+	assert.Equal(t, []template.JS{"SomePlugin"}, cfg.Plugins)
+}
+
+func TestTypedValuesIndex(t *testing.T) {
+	router := http.NewServeMux()
+	router.Handle("/swagger/", Handler(UIConfigValues(map[string]UIValue{
+		"filter": StringValue("abc"),
+	})))
+	router.Handle("/invalid/", Handler(PluginNames("alert(1)")))
+
+	w := performRequest(http.MethodGet, "/swagger/index.html", router)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "\n    \"filter\": \"abc\",\n")
+
+	w = performRequest(http.MethodGet, "/swagger/swagger-config.json", router)
+	assert.Contains(t, w.Body.String(), `"filter":"abc"`)
+
+	for _, target := range []string{"/invalid/index.html", "/invalid/swagger-config.json", "/invalid/swagger-initializer.js"} {
+		w = performRequest(http.MethodGet, target, router)
+		assert.Equal(t, http.StatusInternalServerError, w.Code, target)
+		assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"), target)
+		assert.Equal(t, "Internal Server Error\n", w.Body.String(), target)
+	}
+
+	assert.Equal(t, http.StatusOK, performRequest(http.MethodGet, "/invalid/swagger-ui.css", router).Code)
+}
@@ -0,0 +1,249 @@
+package httpSwagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type docFormat int
+
+const (
+	jsonFormat docFormat = iota
+	yamlFormat
+)
+
+const (
+	jsonContentType = "application/json; charset=utf-8"
+	yamlContentType = "application/yaml; charset=utf-8"
+)
+
+func (f docFormat) contentType() string {
+	if f == yamlFormat {
+		return yamlContentType
+	}
+
+	return jsonContentType
+}
+
+// negotiateFormat picks the document format preferred by the Accept header of r.
+// The format implied by the requested file extension wins ties and is used
+// when the header expresses no preference.
+func negotiateFormat(r *http.Request, fallback docFormat) docFormat {
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return fallback
+	}
+
+	var jsonQ, yamlQ float64
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+
+		switch mediaType {
+		case "application/json":
+			jsonQ = maxFloat(jsonQ, q)
+		default:
+			if isYAML(mediaType) {
+				yamlQ = maxFloat(yamlQ, q)
+			}
+		}
+	}
+
+	switch {
+	case yamlQ > jsonQ:
+		return yamlFormat
+	case jsonQ > yamlQ:
+		return jsonFormat
+	default:
+		return fallback
+	}
+}
+
+func maxFloat(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+// jsonToYAML converts a JSON document to YAML, preserving the order of object keys.
+func jsonToYAML(doc []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+
+	node, err := decodeYAMLNode(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after top-level JSON value")
+	}
+
+	var buf bytes.Buffer
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(node); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func decodeYAMLNode(dec *json.Decoder) (*yaml.Node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := tok.(type) {
+	case json.Delim:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if v == '{' {
+			node.Kind, node.Tag = yaml.MappingNode, "!!map"
+		}
+
+		for dec.More() {
+			if node.Kind == yaml.MappingNode {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key.(string)})
+			}
+
+			value, err := decodeYAMLNode(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			node.Content = append(node.Content, value)
+		}
+
+		// consume the closing delimiter
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+
+		return node, nil
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}, nil
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(v.String(), ".eE") {
+			tag = "!!float"
+		}
+
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: v.String()}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(v)}, nil
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+}
+
+// yamlToJSON converts a YAML document to JSON, preserving the order of mapping keys.
+func yamlToJSON(doc []byte) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(doc, &node); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := encodeYAMLNode(&buf, &node); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func encodeYAMLNode(buf *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			buf.WriteString("null")
+
+			return nil
+		}
+
+		return encodeYAMLNode(buf, node.Content[0])
+	case yaml.AliasNode:
+		return encodeYAMLNode(buf, node.Alias)
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			key, err := json.Marshal(node.Content[i].Value)
+			if err != nil {
+				return err
+			}
+
+			buf.Write(key)
+			buf.WriteByte(':')
+
+			if err := encodeYAMLNode(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte('}')
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+
+		for i, item := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := encodeYAMLNode(buf, item); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte(']')
+	default:
+		var v interface{}
+		if err := node.Decode(&v); err != nil {
+			return err
+		}
+
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		buf.Write(b)
+	}
+
+	return nil
+}
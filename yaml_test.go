@@ -0,0 +1,105 @@
+package httpSwagger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggo/swag"
+)
+
+func TestJSONToYAML(t *testing.T) {
+	out, err := jsonToYAML([]byte(`{"swagger":"2.0","info":{"title":"API","version":"1.0"},"paths":{},"tags":[{"name":"a"},{"name":"true"}],"x-rate":1.5,"x-null":null}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `swagger: "2.0"
+info:
+  title: API
+  version: "1.0"
+paths: {}
+tags:
+  - name: a
+  - name: "true"
+x-rate: 1.5
+x-null: null
+`, string(out))
+
+	_, err = jsonToYAML([]byte(`{"swagger":`))
+	assert.Error(t, err)
+
+	_, err = jsonToYAML([]byte(`{} {}`))
+	assert.Error(t, err)
+}
+
+func TestYAMLToJSON(t *testing.T) {
+	out, err := yamlToJSON([]byte(`swagger: "2.0"
+info: &info
+  title: API
+  version: 1.0
+paths: {}
+x-copy: *info
+x-list: [1, true, ~, "x"]
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"swagger":"2.0","info":{"title":"API","version":1},"paths":{},"x-copy":{"title":"API","version":1},"x-list":[1,true,null,"x"]}`, string(out))
+
+	out, err = yamlToJSON(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "null", string(out))
+
+	_, err = yamlToJSON([]byte("a: [b"))
+	assert.Error(t, err)
+}
+
+func TestNegotiateFormat(t *testing.T) {
+	tests := []struct {
+		accept   string
+		fallback docFormat
+		exp      docFormat
+	}{
+		{accept: "", fallback: jsonFormat, exp: jsonFormat},
+		{accept: "", fallback: yamlFormat, exp: yamlFormat},
+		{accept: "*/*", fallback: yamlFormat, exp: yamlFormat},
+		{accept: "application/yaml", fallback: jsonFormat, exp: yamlFormat},
+		{accept: "application/x-yaml", fallback: jsonFormat, exp: yamlFormat},
+		{accept: "application/json", fallback: yamlFormat, exp: jsonFormat},
+		{accept: "application/json;q=0.5, application/yaml", fallback: jsonFormat, exp: yamlFormat},
+		{accept: "application/json, application/yaml", fallback: yamlFormat, exp: yamlFormat},
+		{accept: "application/json,*/*", fallback: jsonFormat, exp: jsonFormat},
+	}
+
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/doc.json", nil)
+		r.Header.Set("Accept", test.accept)
+		assert.Equal(t, test.exp, negotiateFormat(r, test.fallback), test.accept)
+	}
+}
+
+func TestYAMLDoc(t *testing.T) {
+	swag.Register("yaml", &mockedSwag{})
+
+	router := http.NewServeMux()
+	router.Handle("/swagger/", Handler(InstanceName("yaml")))
+
+	w1 := performRequest(http.MethodGet, "/swagger/doc.yaml", router)
+	assert.Equal(t, http.StatusOK, w1.Code)
+	assert.Equal(t, "application/yaml; charset=utf-8", w1.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", w1.Header().Get("Vary"))
+	assert.Contains(t, w1.Body.String(), "swagger: \"2.0\"\ninfo:\n  description: This is a sample server Petstore server.\n")
+
+	r := httptest.NewRequest(http.MethodGet, "/swagger/doc.json", nil)
+	r.Header.Set("Accept", "application/yaml")
+	w2 := httptest.NewRecorder()
+	router.ServeHTTP(w2, r)
+	assert.Equal(t, http.StatusOK, w2.Code)
+	assert.Equal(t, "application/yaml; charset=utf-8", w2.Header().Get("Content-Type"))
+	assert.Equal(t, w1.Body.String(), w2.Body.String())
+
+	r = httptest.NewRequest(http.MethodGet, "/swagger/doc.yaml", nil)
+	r.Header.Set("Accept", "application/json")
+	w3 := httptest.NewRecorder()
+	router.ServeHTTP(w3, r)
+	assert.Equal(t, http.StatusOK, w3.Code)
+	assert.Equal(t, "application/json; charset=utf-8", w3.Header().Get("Content-Type"))
+	assert.Equal(t, (&mockedSwag{}).ReadDoc(), w3.Body.String())
+}